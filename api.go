@@ -0,0 +1,52 @@
+package yandex_taxi_go
+
+import "context"
+
+//go:generate mockgen -destination=mocks/fleet_api.go -package=mocks . FleetAPI,ParkAPI
+
+// FleetAPI Методы Fleet API, которые реализует Client. Удобно для подмены клиента в тестах
+type FleetAPI interface {
+	// GetCarsList Получение списка автомобилей
+	GetCarsList(ctx context.Context, args GetCarsListArgs, opts ...CallOption) (*GetCarsListResult, error)
+	// GetDriverProfiles Получение списка профилей водителей
+	GetDriverProfiles(ctx context.Context, args GetDriverProfilesArgs, opts ...CallOption) (*GetDriverProfilesResult, error)
+	// StreamDriverProfiles Потоковое получение списка профилей водителей
+	StreamDriverProfiles(ctx context.Context, args GetDriverProfilesArgs, fn func(DriverProfile) error, opts ...CallOption) (*DriverProfilesPage, error)
+	// ScanDriverProfiles Параллельная загрузка всех профилей водителей
+	ScanDriverProfiles(ctx context.Context, args GetDriverProfilesArgs, concurrency int, opts ...CallOption) (*GetDriverProfilesResult, error)
+	// SnapshotDriverProfiles Полный список профилей водителей с защитой от сдвигов пагинации
+	SnapshotDriverProfiles(ctx context.Context, args GetDriverProfilesArgs, snap SnapshotOptions, opts ...CallOption) (*DriverProfilesSnapshot, error)
+	// GetOrdersList Получение страницы списка заказов
+	GetOrdersList(ctx context.Context, args GetOrdersListArgs, opts ...CallOption) (*GetOrdersListResult, error)
+	// GetParkTransactions Получение страницы списка транзакций парка
+	GetParkTransactions(ctx context.Context, args GetTransactionsListArgs, opts ...CallOption) (*GetTransactionsListResult, error)
+	// GetDriverTransactions Получение страницы списка транзакций водителя
+	GetDriverTransactions(ctx context.Context, args GetTransactionsListArgs, opts ...CallOption) (*GetTransactionsListResult, error)
+}
+
+// ParkAPI Методы Fleet API для одного парка, которые реализует ParkClient
+type ParkAPI interface {
+	// ID Идентификатор парка
+	ID() ParkID
+	// GetCarsList Получение списка автомобилей парка
+	GetCarsList(ctx context.Context, args ParkCarsListArgs, opts ...CallOption) (*GetCarsListResult, error)
+	// GetDriverProfiles Получение списка профилей водителей парка
+	GetDriverProfiles(ctx context.Context, args ParkDriverProfilesArgs, opts ...CallOption) (*GetDriverProfilesResult, error)
+	// StreamDriverProfiles Потоковое получение списка профилей водителей парка
+	StreamDriverProfiles(ctx context.Context, args ParkDriverProfilesArgs, fn func(DriverProfile) error, opts ...CallOption) (*DriverProfilesPage, error)
+	// ScanDriverProfiles Параллельная загрузка всех профилей водителей парка
+	ScanDriverProfiles(ctx context.Context, args ParkDriverProfilesArgs, concurrency int, opts ...CallOption) (*GetDriverProfilesResult, error)
+	// SnapshotDriverProfiles Полный список профилей водителей парка с защитой от сдвигов пагинации
+	SnapshotDriverProfiles(ctx context.Context, args ParkDriverProfilesArgs, snap SnapshotOptions, opts ...CallOption) (*DriverProfilesSnapshot, error)
+	// GetOrdersList Получение страницы списка заказов парка
+	GetOrdersList(ctx context.Context, args ParkOrdersListArgs, opts ...CallOption) (*GetOrdersListResult, error)
+	// GetTransactions Получение страницы списка транзакций парка
+	GetTransactions(ctx context.Context, args ParkTransactionsArgs, opts ...CallOption) (*GetTransactionsListResult, error)
+	// GetDriverTransactions Получение страницы списка транзакций водителя парка
+	GetDriverTransactions(ctx context.Context, driverID DriverID, args ParkTransactionsArgs, opts ...CallOption) (*GetTransactionsListResult, error)
+}
+
+var (
+	_ FleetAPI = (*Client)(nil)
+	_ ParkAPI  = (*ParkClient)(nil)
+)
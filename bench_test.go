@@ -0,0 +1,96 @@
+package yandex_taxi_go_test
+
+import (
+	"context"
+	fleet "github.com/sinland/yandex-taxi-go"
+	"github.com/sinland/yandex-taxi-go/fixtures"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newBenchmarkClient Клиент к серверу, который на любой запрос отдает страницу из n профилей
+func newBenchmarkClient(b *testing.B, n int) *fleet.Client {
+	f := fixtures.New(1)
+	parks := []fleet.DriverProfilePark{{Id: "park-id", City: "Москва", Name: "Park"}}
+
+	body, err := fixtures.DriverProfilesResponseJSON(n, 0, n, parks, f.DriverProfiles(n, fixtures.InPark("park-id")))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(body)
+	}))
+	b.Cleanup(server.Close)
+
+	return fleet.NewClient(fleet.ClientConfig{ClientID: "client-id", APIKey: "api-key"}, fleet.WithAPIHost(server.URL))
+}
+
+func BenchmarkClient_GetDriverProfiles(b *testing.B) {
+	c := newBenchmarkClient(b, 1000)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		res, err := c.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{ParkID: "park-id"})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(res.DriverProfiles) != 1000 {
+			b.Fatalf("got %d profiles", len(res.DriverProfiles))
+		}
+	}
+}
+
+func BenchmarkClient_StreamDriverProfiles(b *testing.B) {
+	c := newBenchmarkClient(b, 1000)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		page, err := c.StreamDriverProfiles(ctx, fleet.GetDriverProfilesArgs{ParkID: "park-id"}, func(fleet.DriverProfile) error {
+			return nil
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if page.Count != 1000 {
+			b.Fatalf("got %d profiles", page.Count)
+		}
+	}
+}
+
+func BenchmarkClient_GetCarsList(b *testing.B) {
+	f := fixtures.New(1)
+
+	body, err := fixtures.CarsListResponseJSON(1000, 0, 1000, f.Vehicles(1000))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(body)
+	}))
+	b.Cleanup(server.Close)
+
+	c := fleet.NewClient(fleet.ClientConfig{ClientID: "client-id", APIKey: "api-key"}, fleet.WithAPIHost(server.URL))
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		res, err := c.GetCarsList(ctx, fleet.GetCarsListArgs{ParkID: "park-id"})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(res.Cars) != 1000 {
+			b.Fatalf("got %d cars", len(res.Cars))
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package yandex_taxi_go
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+const defaultCacheSize = 1024
+
+// CacheEntry Сохраненный ответ API
+type CacheEntry struct {
+	Body     []byte    // Тело успешного ответа
+	StoredAt time.Time // Время получения ответа
+}
+
+// Cache Хранилище ответов API. Реализация должна быть безопасна для конкурентного использования
+type Cache interface {
+	// Get Возвращает запись по ключу
+	Get(key string) (CacheEntry, bool)
+	// Set Сохраняет запись по ключу
+	Set(key string, entry CacheEntry)
+}
+
+// CachePolicy Настройки кэширования ответов
+type CachePolicy struct {
+	Storage      Cache                    // Хранилище. По умолчанию LRU на 1024 записи
+	TTL          map[string]time.Duration // Время жизни ответа для пути метода API, например EndpointCarsList
+	DefaultTTL   time.Duration            // Время жизни для методов, не указанных в TTL. 0 - такие методы не кэшируются
+	StaleIfError time.Duration            // Сколько после истечения TTL можно отдавать устаревший ответ, если API недоступен
+}
+
+// ResponseMeta Сведения о происхождении ответа
+type ResponseMeta struct {
+	FromCache bool          // Ответ взят из кэша
+	Stale     bool          // Ответ устарел и отдан из-за ошибки API
+	Age       time.Duration // Возраст ответа из кэша
+	Err       error         // Ошибка API, из-за которой отдан устаревший ответ
+	Shared    bool          // Ответ получен запросом, общим с другими одновременными вызовами
+}
+
+// WithCache Включает кэширование ответов. Ключ кэша - путь метода, данные авторизации, язык ответа и тело запроса.
+// Хранилище можно разделять между клиентами с разными данными авторизации
+func WithCache(policy CachePolicy) func(client *Client) {
+	return func(s *Client) {
+		if policy.Storage == nil {
+			policy.Storage = NewLRUCache(defaultCacheSize)
+		}
+		s.cache = &responseCache{policy: policy, now: time.Now}
+	}
+}
+
+type responseCache struct {
+	policy CachePolicy
+	now    func() time.Time
+}
+
+func (c *responseCache) ttl(endpoint string) time.Duration {
+	if ttl, ok := c.policy.TTL[endpoint]; ok {
+		return ttl
+	}
+	return c.policy.DefaultTTL
+}
+
+// cached Выполняет запрос через кэш: свежий ответ берется из хранилища, новый ответ сохраняется,
+// а при ошибке API отдается устаревший ответ, если он не старше TTL+StaleIfError
+func (c *Client) cached(ctx context.Context, info CallInfo, reqData any, resData any, opts []CallOption) (ResponseMeta, error) {
+	ttl := c.cache.ttl(info.Endpoint)
+	callOpts := c.callOptions(opts)
+	key, err := c.requestKey(ctx, info, reqData, opts)
+	if err != nil {
+		return ResponseMeta{}, err
+	}
+
+	entry, found := c.cache.policy.Storage.Get(key)
+	age := c.cache.now().Sub(entry.StoredAt)
+	if found && !callOpts.noCache && age < ttl {
+		return ResponseMeta{FromCache: true, Age: age}, json.Unmarshal(entry.Body, resData)
+	}
+
+	data, shared, err := c.fetchBody(ctx, info, reqData, opts)
+	if err == nil {
+		if err = json.Unmarshal(data, resData); err != nil {
+			return ResponseMeta{}, err
+		}
+		c.cache.policy.Storage.Set(key, CacheEntry{Body: data, StoredAt: c.cache.now()})
+		return ResponseMeta{Shared: shared}, nil
+	}
+
+	if found && staleAllowed(ctx, err) && age < ttl+c.cache.policy.StaleIfError {
+		c.getLogger().WarnContext(ctx, "fleet api serving stale response",
+			"endpoint", info.Endpoint,
+			"age", age,
+			"error", err,
+		)
+		return ResponseMeta{FromCache: true, Stale: true, Age: age, Err: err}, json.Unmarshal(entry.Body, resData)
+	}
+
+	return ResponseMeta{}, err
+}
+
+// staleAllowed Устаревший ответ отдается при сетевых ошибках, 429 и 5xx, но не при отмене контекста вызывающим
+func staleAllowed(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return retryableStatus(apiErr.StatusCode)
+	}
+	return true
+}
+
+// LRUCache Хранилище ответов в памяти с вытеснением давно не использованных записей
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// NewLRUCache Создает хранилище не более чем на size записей
+func NewLRUCache(size int) *LRUCache {
+	if size < 1 {
+		size = 1
+	}
+	return &LRUCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get Возвращает запись по ключу
+func (c *LRUCache) Get(key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*lruItem).entry, true
+}
+
+// Set Сохраняет запись по ключу, вытесняя самую давно использованную при переполнении
+func (c *LRUCache) Set(key string, entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*lruItem).entry = entry
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruItem{key: key, entry: entry})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruItem).key)
+	}
+}
+
+// Len Число записей в хранилище
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,186 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Cache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	newServer := func(t *testing.T, status *atomic.Int32, requests *atomic.Int32) string {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := requests.Add(1)
+			if code := int(status.Load()); code != http.StatusOK {
+				w.WriteHeader(code)
+				return
+			}
+			bytes, _ := json.Marshal(GetCarsListResult{Total: int(n), Cars: []Vehicle{{Id: "car-id"}}})
+			_, _ = w.Write(bytes)
+		}))
+		t.Cleanup(server.Close)
+		return server.URL
+	}
+
+	newClient := func(url string, policy CachePolicy) (*Client, *time.Time) {
+		c := NewClient(ClientConfig{ClientID: testClientID, APIKey: testAPIKey}, WithAPIHost(url), WithCache(policy))
+		now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		c.cache.now = func() time.Time { return now }
+		return c, &now
+	}
+
+	t.Run("fresh response served from cache", func(t *testing.T) {
+		t.Parallel()
+
+		var status, requests atomic.Int32
+		status.Store(http.StatusOK)
+		c, now := newClient(newServer(t, &status, &requests), CachePolicy{
+			TTL: map[string]time.Duration{EndpointCarsList: time.Minute},
+		})
+
+		res, err := c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-1"})
+		require.NoError(t, err)
+		require.Equal(t, ResponseMeta{}, res.Meta)
+
+		*now = now.Add(30 * time.Second)
+		res, err = c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-1"})
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Total)
+		require.Equal(t, ResponseMeta{FromCache: true, Age: 30 * time.Second}, res.Meta)
+		require.Equal(t, int32(1), requests.Load())
+
+		res.Cars[0].Id = "changed"
+		res, err = c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-1"})
+		require.NoError(t, err)
+		require.Equal(t, CarID("car-id"), res.Cars[0].Id)
+
+		_, err = c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-2"})
+		require.NoError(t, err)
+		_, err = c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-1"}, WithCallLanguage("kk"))
+		require.NoError(t, err)
+		require.Equal(t, int32(3), requests.Load())
+
+		res, err = c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-1"}, WithCallNoCache())
+		require.NoError(t, err)
+		require.False(t, res.Meta.FromCache)
+		require.Equal(t, int32(4), requests.Load())
+
+		*now = now.Add(time.Minute)
+		res, err = c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-1"})
+		require.NoError(t, err)
+		require.False(t, res.Meta.FromCache)
+		require.Equal(t, 5, res.Total)
+	})
+
+	t.Run("endpoint without ttl is not cached", func(t *testing.T) {
+		t.Parallel()
+
+		var status, requests atomic.Int32
+		status.Store(http.StatusOK)
+		c, _ := newClient(newServer(t, &status, &requests), CachePolicy{
+			TTL: map[string]time.Duration{EndpointDriverProfilesList: time.Minute},
+		})
+
+		for i := 0; i < 2; i++ {
+			_, err := c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-1"})
+			require.NoError(t, err)
+		}
+		require.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("shared storage is keyed by credentials", func(t *testing.T) {
+		t.Parallel()
+
+		var status, requests atomic.Int32
+		status.Store(http.StatusOK)
+		url := newServer(t, &status, &requests)
+		policy := CachePolicy{Storage: NewLRUCache(16), DefaultTTL: time.Minute}
+
+		c := NewClient(ClientConfig{ClientID: testClientID, APIKey: testAPIKey}, WithAPIHost(url), WithCache(policy))
+		sameKey := NewClient(ClientConfig{ClientID: testClientID, APIKey: testAPIKey}, WithAPIHost(url), WithCache(policy))
+		otherKey := NewClient(ClientConfig{ClientID: testClientID, APIKey: "other-key"}, WithAPIHost(url), WithCache(policy))
+		otherClient := NewClient(ClientConfig{ClientID: "other-client", APIKey: testAPIKey}, WithAPIHost(url), WithCache(policy))
+
+		_, err := c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-1"})
+		require.NoError(t, err)
+
+		res, err := sameKey.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-1"})
+		require.NoError(t, err)
+		require.True(t, res.Meta.FromCache)
+
+		for _, other := range []*Client{otherKey, otherClient} {
+			res, err = other.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-1"})
+			require.NoError(t, err)
+			require.False(t, res.Meta.FromCache, "response must not be shared across credentials")
+		}
+		require.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("stale if error", func(t *testing.T) {
+		t.Parallel()
+
+		var status, requests atomic.Int32
+		status.Store(http.StatusOK)
+		c, now := newClient(newServer(t, &status, &requests), CachePolicy{
+			DefaultTTL:   time.Minute,
+			StaleIfError: 10 * time.Minute,
+		})
+
+		_, err := c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-1"})
+		require.NoError(t, err)
+
+		status.Store(http.StatusServiceUnavailable)
+		*now = now.Add(5 * time.Minute)
+
+		res, err := c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-1"})
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Total)
+		require.True(t, res.Meta.FromCache)
+		require.True(t, res.Meta.Stale)
+		require.Equal(t, 5*time.Minute, res.Meta.Age)
+		require.EqualError(t, res.Meta.Err, "[503] Service Unavailable ()")
+
+		status.Store(http.StatusBadRequest)
+		_, err = c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-1"})
+		require.EqualError(t, err, "[400] Bad Request ()")
+
+		status.Store(http.StatusServiceUnavailable)
+		*now = now.Add(6 * time.Minute)
+		_, err = c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-1"})
+		require.EqualError(t, err, "[503] Service Unavailable ()")
+	})
+}
+
+func TestLRUCache(t *testing.T) {
+	t.Parallel()
+
+	c := NewLRUCache(2)
+	c.Set("a", CacheEntry{Body: []byte("a")})
+	c.Set("b", CacheEntry{Body: []byte("b")})
+
+	_, ok := c.Get("a")
+	require.True(t, ok)
+
+	c.Set("c", CacheEntry{Body: []byte("c")})
+	require.Equal(t, 2, c.Len())
+
+	_, ok = c.Get("b")
+	require.False(t, ok)
+
+	entry, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, []byte("a"), entry.Body)
+
+	c.Set("a", CacheEntry{Body: []byte("a2")})
+	entry, _ = c.Get("a")
+	require.Equal(t, []byte("a2"), entry.Body)
+	require.Equal(t, 2, c.Len())
+}
@@ -0,0 +1,155 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Checkpoint Позиция синхронизации, с которой она продолжится после перезапуска
+type Checkpoint struct {
+	Watermark time.Time            `json:"watermark"`        // Время, до которого данные уже обработаны
+	Cursor    string               `json:"cursor,omitempty"` // Курсор API, если метод поддерживает курсоры
+	Window    *TimeRange           `json:"window,omitempty"` // Окно запроса, к которому относится Cursor
+	Seen      map[string]time.Time `json:"seen,omitempty"`   // Уже обработанные записи в окне перекрытия и их время
+}
+
+// CheckpointStore Хранилище позиций синхронизации. Реализация должна быть безопасна для конкурентного использования
+type CheckpointStore interface {
+	// Load Возвращает позицию по ключу. Признак found равен false, если позиция еще не сохранялась
+	Load(ctx context.Context, key string) (cp Checkpoint, found bool, err error)
+	// Save Сохраняет позицию по ключу
+	Save(ctx context.Context, key string, cp Checkpoint) error
+}
+
+// MemoryCheckpointStore Хранилище позиций в памяти. Позиции теряются при перезапуске процесса
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]Checkpoint
+}
+
+// NewMemoryCheckpointStore Создает хранилище позиций в памяти
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: make(map[string]Checkpoint)}
+}
+
+// Load Возвращает позицию по ключу
+func (s *MemoryCheckpointStore) Load(_ context.Context, key string) (Checkpoint, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cp, ok := s.checkpoints[key]
+	return cp, ok, nil
+}
+
+// Save Сохраняет позицию по ключу
+func (s *MemoryCheckpointStore) Save(_ context.Context, key string, cp Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkpoints[key] = cp
+	return nil
+}
+
+// FileCheckpointStore Хранилище позиций в JSON-файле. Все позиции хранятся в одном файле, который
+// перезаписывается атомарно через временный файл, поэтому сбой во время записи не портит сохраненные позиции
+type FileCheckpointStore struct {
+	mu          sync.Mutex
+	path        string
+	checkpoints map[string]Checkpoint
+}
+
+// NewFileCheckpointStore Создает хранилище позиций в файле path. Файл создается при первом сохранении
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+// load Читает файл при первом обращении
+func (s *FileCheckpointStore) load() error {
+	if s.checkpoints != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		s.checkpoints = make(map[string]Checkpoint)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	checkpoints := make(map[string]Checkpoint)
+	if err = json.Unmarshal(data, &checkpoints); err != nil {
+		return fmt.Errorf("decode %s: %w", s.path, err)
+	}
+	s.checkpoints = checkpoints
+	return nil
+}
+
+// Load Возвращает позицию по ключу
+func (s *FileCheckpointStore) Load(_ context.Context, key string) (Checkpoint, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return Checkpoint{}, false, err
+	}
+	cp, ok := s.checkpoints[key]
+	return cp, ok, nil
+}
+
+// Save Сохраняет позицию по ключу и записывает файл
+func (s *FileCheckpointStore) Save(_ context.Context, key string, cp Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+
+	checkpoints := make(map[string]Checkpoint, len(s.checkpoints)+1)
+	for k, v := range s.checkpoints {
+		checkpoints[k] = v
+	}
+	checkpoints[key] = cp
+
+	data, err := json.MarshalIndent(checkpoints, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = writeFileAtomic(s.path, data); err != nil {
+		return err
+	}
+
+	s.checkpoints = checkpoints
+	return nil
+}
+
+// writeFileAtomic Записывает файл через временный файл в том же каталоге и переименование
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,55 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileCheckpointStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+	watermark := time.Date(2024, time.March, 3, 10, 0, 0, 0, time.UTC)
+
+	store := NewFileCheckpointStore(path)
+	_, found, err := store.Load(ctx, "orders/park-1")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	cp := Checkpoint{
+		Watermark: watermark,
+		Cursor:    "next",
+		Window:    &TimeRange{From: watermark, To: watermark.Add(time.Hour)},
+		Seen:      map[string]time.Time{"order-1": watermark.Add(time.Minute)},
+	}
+	require.NoError(t, store.Save(ctx, "orders/park-1", cp))
+	require.NoError(t, store.Save(ctx, "drivers/park-1", Checkpoint{Watermark: watermark}))
+
+	reopened := NewFileCheckpointStore(path)
+	got, found, err := reopened.Load(ctx, "orders/park-1")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, cp, got)
+
+	got, found, err = reopened.Load(ctx, "drivers/park-1")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.True(t, watermark.Equal(got.Watermark))
+	require.Nil(t, got.Window)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary files must be removed")
+
+	t.Run("corrupted file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+		_, _, err := NewFileCheckpointStore(path).Load(ctx, "orders/park-1")
+		require.ErrorContains(t, err, "decode")
+	})
+}
@@ -1,10 +1,7 @@
 package yandex_taxi_go
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
 	"github.com/sinland/yandex-taxi-go/internal/models"
 	"io"
 	"log/slog"
@@ -13,15 +10,19 @@ import (
 )
 
 const (
-	defaultApiHost   = "https://fleet-api.taxi.yandex.net"
-	defaultPageLimit = 1000
+	defaultApiHost        = "https://fleet-api.taxi.yandex.net"
+	defaultPageLimit      = 1000
+	maxPageLimit          = 1000
+	defaultOrderPageLimit = 500
+	defaultLanguage       = "ru"
 
 	contentTypeJson = "application/json"
 
-	headerContentType    = "Content-Type"
-	headerAcceptLanguage = "Accept-Language"
-	headerXAPIKey        = "X-API-Key"
-	headerXCientID       = "X-Client-ID"
+	headerContentType       = "Content-Type"
+	headerAcceptLanguage    = "Accept-Language"
+	headerXAPIKey           = "X-API-Key"
+	headerXCientID          = "X-Client-ID"
+	headerXIdempotencyToken = "X-Idempotency-Token"
 )
 
 type httpClient interface {
@@ -32,18 +33,30 @@ type httpClient interface {
 
 // Client main api client
 type Client struct {
-	clientId   string
-	apiKey     string
-	apiHost    string
-	httpClient httpClient
+	credentials CredentialsProvider
+	apiHost     string
+	language    string
+	httpClient  httpClient
+
+	logger      *slog.Logger
+	redaction   RedactionPolicy
+	metrics     Metrics
+	retry       *RetryPolicy
+	limiter     *rateLimiter
+	cache       *responseCache
+	flights     *flightGroup
+	middlewares []Middleware
+	roundTrip   RoundTrip
 }
 
 // NewClient constructor
 func NewClient(cfg ClientConfig, opts ...ClientOption) *Client {
 	c := &Client{
-		apiKey:   cfg.APIKey,
-		clientId: cfg.ClientID,
-		apiHost:  defaultApiHost,
+		credentials: NewStaticCredentials(cfg.ClientID, cfg.APIKey),
+		apiHost:     defaultApiHost,
+		language:    defaultLanguage,
+		redaction:   DefaultRedactionPolicy(),
+		metrics:     nopMetrics{},
 	}
 
 	c.httpClient = &http.Client{
@@ -54,6 +67,8 @@ func NewClient(cfg ClientConfig, opts ...ClientOption) *Client {
 		opt(c)
 	}
 
+	c.roundTrip = chainMiddlewares(c.httpClient.Do, c.pipeline())
+
 	return c
 }
 
@@ -76,215 +91,225 @@ func WithAPIHost(apiHost string) func(client *Client) {
 	}
 }
 
-// GetCarsList Получение списка автомобилей
-func (c *Client) GetCarsList(ctx context.Context, args GetCarsListArgs) (*GetCarsListResult, error) {
-	reqUrl := fmt.Sprintf("%s/v1/parks/cars/list", c.apiHost)
+// WithLanguage Язык ответов API по умолчанию (заголовок Accept-Language), например "ru", "kk", "uz".
+// Пустая строка отключает отправку заголовка
+func WithLanguage(language string) func(client *Client) {
+	return func(s *Client) {
+		s.language = language
+	}
+}
 
+// WithMiddleware Добавляет middleware в цепочку обработки запросов. Первый переданный middleware
+// оказывается внешним: он первым получает запрос и последним - ответ
+func WithMiddleware(middlewares ...Middleware) func(client *Client) {
+	return func(s *Client) {
+		s.middlewares = append(s.middlewares, middlewares...)
+	}
+}
+
+// GetCarsList Получение списка автомобилей
+func (c *Client) GetCarsList(ctx context.Context, args GetCarsListArgs, opts ...CallOption) (*GetCarsListResult, error) {
 	limit := args.Limit
 	if limit == 0 {
 		limit = defaultPageLimit
 	}
 
-	body, err := json.Marshal(models.CarsListRequest{
+	reqData := models.CarsListRequest{
 		Limit:  limit,
 		Offset: args.Page * limit,
 		Query: models.CarsListQuery{
 			Park: models.CarsListQueryPark{
-				Id: args.ParkID,
+				Id: string(args.ParkID),
 			},
 		},
-	})
-	if err != nil {
-		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqUrl, bytes.NewReader(body))
-	if err != nil {
-		return nil, err
+	info := CallInfo{
+		Endpoint: EndpointCarsList,
+		ParkID:   args.ParkID,
+		Offset:   reqData.Offset,
 	}
-	req.Header.Set(headerContentType, contentTypeJson)
-	req.Header.Set(headerXAPIKey, c.apiKey)
-	req.Header.Set(headerXCientID, c.clientId)
 
-	slog.DebugContext(ctx, "querying cars list", "url", reqUrl, "body", string(body))
-	res, err := c.httpClient.Do(req)
+	result := &GetCarsListResult{}
+	meta, err := c.do(ctx, info, reqData, result, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
+	result.Meta = meta
 
-	slog.DebugContext(ctx, "query result", "status_code", res.StatusCode, "status", res.Status)
-	if res.StatusCode != http.StatusOK {
-		resData := models.ErrorResponse{}
-		if err = json.NewDecoder(res.Body).Decode(&resData); err != nil {
-			return nil, err
-		}
-		return nil, fmt.Errorf("[%d] %s (%s)", res.StatusCode, resData.Message, resData.Code)
-	}
+	return result, nil
+}
 
-	var resData models.CarsListResponse
-	if err = json.NewDecoder(res.Body).Decode(&resData); err != nil {
-		return nil, err
-	}
+func (c *Client) GetDriverProfiles(ctx context.Context, args GetDriverProfilesArgs, opts ...CallOption) (*GetDriverProfilesResult, error) {
+	reqData := driverProfilesRequest(args)
 
-	result := &GetCarsListResult{
-		Total:  resData.Total,
-		Offset: resData.Offset,
-		Limit:  resData.Limit,
-		Cars:   make([]Vehicle, 0, len(resData.Cars)),
+	info := CallInfo{
+		Endpoint: EndpointDriverProfilesList,
+		ParkID:   args.ParkID,
+		Offset:   reqData.Offset,
 	}
 
-	for i := range resData.Cars {
-		result.Cars = append(result.Cars, Vehicle{
-			Id:               resData.Cars[i].Id,
-			Amenities:        resData.Cars[i].Amenities,
-			Brand:            resData.Cars[i].Brand,
-			Callsign:         resData.Cars[i].Callsign,
-			Category:         resData.Cars[i].Category,
-			Color:            resData.Cars[i].Color,
-			Model:            resData.Cars[i].Model,
-			Number:           resData.Cars[i].Number,
-			RegistrationCert: resData.Cars[i].RegistrationCert,
-			Status:           resData.Cars[i].Status,
-			Vin:              resData.Cars[i].Vin,
-			Year:             resData.Cars[i].Year,
-		})
+	result := &GetDriverProfilesResult{}
+	meta, err := c.do(ctx, info, reqData, result, opts)
+	if err != nil {
+		return nil, err
 	}
+	result.Meta = meta
 
 	return result, nil
 }
 
-func (c *Client) GetDriverProfiles(ctx context.Context, args GetDriverProfilesArgs) (*GetDriverProfilesResult, error) {
-	reqUrl := fmt.Sprintf("%s/v1/parks/driver-profiles/list", c.apiHost)
-
+// GetOrdersList Получение страницы списка заказов, забронированных в полуинтервале args.BookedAt.
+// Следующая страница запрашивается с курсором из результата
+func (c *Client) GetOrdersList(ctx context.Context, args GetOrdersListArgs, opts ...CallOption) (*GetOrdersListResult, error) {
 	limit := args.Limit
 	if limit == 0 {
-		limit = defaultPageLimit
+		limit = defaultOrderPageLimit
 	}
 
-	body, err := json.Marshal(models.DriverProfilesRequest{
-		Offset: args.Offset,
+	reqData := models.OrdersListRequest{
+		Cursor: args.Cursor,
 		Limit:  limit,
-		Query: models.DriverProfilesListRequestQuery{
-			Park: &models.DriverProfilesListRequestQueryPark{Id: args.ParkId},
-			Text: args.QueryText,
+		Query: models.OrdersListRequestQuery{
+			Park: models.OrdersListRequestQueryPark{
+				Id: string(args.ParkID),
+				Order: models.OrdersListRequestQueryParkOrder{
+					BookedAt: timeInterval(args.BookedAt),
+					Statuses: args.Statuses,
+				},
+			},
 		},
-	})
-	if err != nil {
-		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqUrl, bytes.NewReader(body))
+	info := CallInfo{
+		Endpoint: EndpointOrdersList,
+		ParkID:   args.ParkID,
+	}
+
+	result := &GetOrdersListResult{}
+	meta, err := c.do(ctx, info, reqData, result, opts)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set(headerContentType, contentTypeJson)
-	req.Header.Set(headerXAPIKey, c.apiKey)
-	req.Header.Set(headerXCientID, c.clientId)
-	req.Header.Set(headerAcceptLanguage, "ru")
+	result.Meta = meta
+
+	return result, nil
+}
+
+// GetParkTransactions Получение страницы списка транзакций парка за полуинтервал args.EventAt.
+// Следующая страница запрашивается с курсором из результата
+func (c *Client) GetParkTransactions(ctx context.Context, args GetTransactionsListArgs, opts ...CallOption) (*GetTransactionsListResult, error) {
+	return c.getTransactions(ctx, EndpointParkTransactions, transactionsRequest(args), args.ParkID, opts)
+}
+
+// GetDriverTransactions Получение страницы списка транзакций водителя args.DriverID за полуинтервал args.EventAt.
+// Следующая страница запрашивается с курсором из результата
+func (c *Client) GetDriverTransactions(ctx context.Context, args GetTransactionsListArgs, opts ...CallOption) (*GetTransactionsListResult, error) {
+	reqData := transactionsRequest(args)
+	reqData.Query.Park.DriverProfile = &models.TransactionsListRequestQueryParkDriverProfile{Id: string(args.DriverID)}
+
+	return c.getTransactions(ctx, EndpointDriverTransactions, reqData, args.ParkID, opts)
+}
+
+func (c *Client) getTransactions(ctx context.Context, endpoint string, reqData models.TransactionsListRequest, parkID ParkID, opts []CallOption) (*GetTransactionsListResult, error) {
+	info := CallInfo{
+		Endpoint: endpoint,
+		ParkID:   parkID,
+	}
 
-	res, err := c.httpClient.Do(req)
+	result := &GetTransactionsListResult{}
+	meta, err := c.do(ctx, info, reqData, result, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
+	result.Meta = meta
 
-	if res.StatusCode != http.StatusOK {
-		resData := models.ErrorResponse{}
-		if err = json.NewDecoder(res.Body).Decode(&resData); err != nil {
-			return nil, err
-		}
-		return nil, fmt.Errorf("[%d] %s (%s)", res.StatusCode, resData.Message, resData.Code)
+	return result, nil
+}
+
+// transactionsRequest Запрос списка транзакций в формате API
+func transactionsRequest(args GetTransactionsListArgs) models.TransactionsListRequest {
+	limit := args.Limit
+	if limit == 0 {
+		limit = defaultPageLimit
 	}
 
-	var resData models.DriverProfilesResponse
-	if err = json.NewDecoder(res.Body).Decode(&resData); err != nil {
-		return nil, err
+	return models.TransactionsListRequest{
+		Cursor: args.Cursor,
+		Limit:  limit,
+		Query: models.TransactionsListRequestQuery{
+			Park: models.TransactionsListRequestQueryPark{
+				Id: string(args.ParkID),
+				Transaction: models.TransactionsListRequestQueryParkTransaction{
+					EventAt:     timeInterval(args.EventAt),
+					CategoryIds: args.CategoryIDs,
+				},
+			},
+		},
 	}
+}
 
-	result := &GetDriverProfilesResult{
-		Total:          resData.Total,
-		Offset:         resData.Offset,
-		Limit:          resData.Limit,
-		DriverProfiles: make([]DriverProfile, 0, len(resData.DriverProfiles)),
-		Parks:          make([]DriverProfilePark, 0, len(resData.Parks)),
+// timeInterval Полуинтервал времени в формате API
+func timeInterval(r TimeRange) models.TimeInterval {
+	return models.TimeInterval{
+		From: r.From.UTC().Format(time.RFC3339Nano),
+		To:   r.To.UTC().Format(time.RFC3339Nano),
 	}
+}
 
-	for i := range resData.Parks {
-		result.Parks = append(result.Parks, DriverProfilePark{
-			Id:   resData.Parks[i].Id,
-			City: resData.Parks[i].City,
-			Name: resData.Parks[i].Name,
-		})
+// driverProfilesRequest Запрос списка профилей водителей в формате API
+func driverProfilesRequest(args GetDriverProfilesArgs) models.DriverProfilesRequest {
+	limit := args.Limit
+	if limit == 0 {
+		limit = defaultPageLimit
 	}
 
-	for i := range resData.DriverProfiles {
-		profile := DriverProfile{
-			Accounts: make([]DriverProfileAccount, 0, len(resData.DriverProfiles[i].Accounts)),
-		}
+	req := models.DriverProfilesRequest{
+		Offset: args.Offset,
+		Limit:  limit,
+		Query: models.DriverProfilesListRequestQuery{
+			Park: &models.DriverProfilesListRequestQueryPark{Id: string(args.ParkID)},
+			Text: args.QueryText,
+		},
+	}
 
-		for j := range resData.DriverProfiles[i].Accounts {
-			profile.Accounts = append(profile.Accounts, DriverProfileAccount{
-				Id:           resData.DriverProfiles[i].Accounts[j].Id,
-				Balance:      resData.DriverProfiles[i].Accounts[j].Balance,
-				BalanceLimit: resData.DriverProfiles[i].Accounts[j].BalanceLimit,
-				Currency:     resData.DriverProfiles[i].Accounts[j].Currency,
-				Type:         resData.DriverProfiles[i].Accounts[j].Type,
-			})
-		}
+	for _, order := range args.SortOrder {
+		req.SortOrder = append(req.SortOrder, models.DriverProfileRequestSortOrderField{
+			Direction: order.Direction,
+			Field:     order.Field,
+		})
+	}
 
-		if resData.DriverProfiles[i].DriverProfile != nil {
-			profile.Profile = &DriverProfileData{
-				Id:           resData.DriverProfiles[i].DriverProfile.Id,
-				CheckMessage: resData.DriverProfiles[i].DriverProfile.CheckMessage,
-				Comment:      resData.DriverProfiles[i].DriverProfile.Comment,
-				CreatedDate:  resData.DriverProfiles[i].DriverProfile.CreatedDate,
-				DriverLicense: DriverLicense{
-					IssueDate:        resData.DriverProfiles[i].DriverProfile.DriverLicense.IssueDate,
-					ExpirationDate:   resData.DriverProfiles[i].DriverProfile.DriverLicense.ExpirationDate,
-					Number:           resData.DriverProfiles[i].DriverProfile.DriverLicense.Number,
-					NormalizedNumber: resData.DriverProfiles[i].DriverProfile.DriverLicense.NormalizedNumber,
-					Country:          resData.DriverProfiles[i].DriverProfile.DriverLicense.Country,
-					BirthDate:        resData.DriverProfiles[i].DriverProfile.DriverLicense.BirthDate,
-				},
-				EmploymentType:   resData.DriverProfiles[i].DriverProfile.EmploymentType,
-				FirstName:        resData.DriverProfiles[i].DriverProfile.FirstName,
-				HasContractIssue: resData.DriverProfiles[i].DriverProfile.HasContractIssue,
-				LastName:         resData.DriverProfiles[i].DriverProfile.LastName,
-				MiddleName:       resData.DriverProfiles[i].DriverProfile.MiddleName,
-				ParkId:           resData.DriverProfiles[i].DriverProfile.ParkId,
-				Phones:           resData.DriverProfiles[i].DriverProfile.Phones,
-				WorkRuleId:       resData.DriverProfiles[i].DriverProfile.WorkRuleId,
-				WorkStatus:       resData.DriverProfiles[i].DriverProfile.WorkStatus,
-			}
+	if r := args.UpdatedAt; r != nil {
+		updatedAt := &models.DriverProfilesListRequestQueryParkUpdatedAt{}
+		if !r.From.IsZero() {
+			updatedAt.From = r.From.UTC().Format(time.RFC3339Nano)
 		}
-
-		if resData.DriverProfiles[i].Car != nil {
-			profile.Car = &Vehicle{
-				Id:               resData.DriverProfiles[i].Car.Id,
-				Amenities:        resData.DriverProfiles[i].Car.Amenities,
-				Brand:            resData.DriverProfiles[i].Car.Brand,
-				Callsign:         resData.DriverProfiles[i].Car.Callsign,
-				Category:         resData.DriverProfiles[i].Car.Category,
-				Color:            resData.DriverProfiles[i].Car.Color,
-				Model:            resData.DriverProfiles[i].Car.Model,
-				Number:           resData.DriverProfiles[i].Car.Number,
-				RegistrationCert: resData.DriverProfiles[i].Car.RegistrationCert,
-				Status:           resData.DriverProfiles[i].Car.Status,
-				Vin:              resData.DriverProfiles[i].Car.Vin,
-				Year:             resData.DriverProfiles[i].Car.Year,
-			}
+		if !r.To.IsZero() {
+			updatedAt.To = r.To.UTC().Format(time.RFC3339Nano)
 		}
+		req.Query.Park.UpdatedAt = updatedAt
+		req.Fields = &models.DriverProfileListRequestFields{UpdatedAt: true}
+	}
 
-		if resData.DriverProfiles[i].CurrentStatus != nil {
-			profile.CurrentStatus = &DriverProfileCurrentStatus{
-				Status:          resData.DriverProfiles[i].CurrentStatus.Status,
-				StatusUpdatedAt: resData.DriverProfiles[i].CurrentStatus.StatusUpdatedAt,
-			}
+	if f := args.Fields; f != nil {
+		if req.Fields == nil {
+			req.Fields = &models.DriverProfileListRequestFields{}
 		}
-
-		result.DriverProfiles = append(result.DriverProfiles, profile)
+		req.Fields.Account = fieldList(f.Account)
+		req.Fields.Car = fieldList(f.Car)
+		req.Fields.CurrentStatus = fieldList(f.CurrentStatus)
+		req.Fields.DriverProfile = fieldList(f.DriverProfile)
+		req.Fields.Park = fieldList(f.Park)
 	}
 
-	return result, nil
+	return req
+}
+
+// fieldList Список полей блока: nil не передается, пустой срез передается как []
+func fieldList(fields []string) *[]string {
+	if fields == nil {
+		return nil
+	}
+	return &fields
 }
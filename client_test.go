@@ -3,7 +3,6 @@ package yandex_taxi_go
 import (
 	"context"
 	"encoding/json"
-	"github.com/brianvoe/gofakeit/v7"
 	"github.com/sinland/yandex-taxi-go/internal/models"
 	"github.com/stretchr/testify/require"
 	"net/http"
@@ -26,7 +25,7 @@ func TestClient_GetCarsList(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		t.Parallel()
 
-		testVehicle := models.Vehicle{
+		testVehicle := Vehicle{
 			Id:               "2111ade6gk054dfdb9iu8c8cc9460mks",
 			Amenities:        []string{"wifi"},
 			Brand:            "Mercedes-Benz",
@@ -46,14 +45,25 @@ func TestClient_GetCarsList(t *testing.T) {
 			require.Equal(t, testClientID, r.Header.Get(headerXCientID))
 
 			w.WriteHeader(http.StatusOK)
-			moqResult := models.CarsListResponse{
-				Total:  1000,
-				Offset: 0,
-				Limit:  1000,
-				Cars:   []models.Vehicle{testVehicle},
-			}
-			bytes, _ := json.Marshal(moqResult)
-			_, err := w.Write(bytes)
+			_, err := w.Write([]byte(`{
+				"total": 1000,
+				"offset": 0,
+				"limit": 1000,
+				"cars": [{
+					"id": "2111ade6gk054dfdb9iu8c8cc9460mks",
+					"amenities": ["wifi"],
+					"brand": "Mercedes-Benz",
+					"callsign": "123456789",
+					"category": ["econom"],
+					"color": "Черный",
+					"model": "E-klasse",
+					"number": "Т8654Т99",
+					"registration_cert": "123456789",
+					"status": "working",
+					"vin": "12345678909876543",
+					"year": 2019
+				}]
+			}`))
 			require.NoError(t, err)
 		}))
 
@@ -134,26 +144,26 @@ func TestClient_GetDriverProfiles(t *testing.T) {
 		args := GetDriverProfilesArgs{
 			Offset:    5,
 			Limit:     1000,
-			ParkId:    "park-id",
+			ParkID:    "park-id",
 			QueryText: "some query text",
 		}
 
-		testPark := models.DriverProfilePark{
-			Id:   gofakeit.UUID(),
-			City: gofakeit.City(),
-			Name: gofakeit.Company(),
+		testPark := DriverProfilePark{
+			Id:   "9b7d5f3a1c8e4a6b2d0f8e6c4a2b0d9f",
+			City: "Москва",
+			Name: "Таксопарк",
 		}
 
-		testProfile := models.DriverProfile{
-			Accounts: []models.DriverProfileAccount{{
-				Id:           gofakeit.UUID(),
+		testProfile := DriverProfile{
+			Accounts: []DriverProfileAccount{{
+				Id:           "7f1c0e6e2b4f4c1a9d5e3b2a1c0d9e8f",
 				Balance:      "1000.00",
 				BalanceLimit: "50.00",
 				Currency:     "RUB",
 				Type:         "current",
 			}},
-			Car: &models.Vehicle{
-				Id:               gofakeit.UUID(),
+			Car: &Vehicle{
+				Id:               "5a8d1f3c9e7b4d2a8c6e4f2a0b9d7c5e",
 				Amenities:        []string{"conditioner"},
 				Brand:            "Mercedes-Benz",
 				Callsign:         "123456789",
@@ -166,16 +176,16 @@ func TestClient_GetDriverProfiles(t *testing.T) {
 				Vin:              "12345678909876543",
 				Year:             2019,
 			},
-			CurrentStatus: &models.DriverProfileCurrentStatus{
+			CurrentStatus: &DriverProfileCurrentStatus{
 				Status:          "busy",
 				StatusUpdatedAt: "2020-04-27T08:44:05.871+0000",
 			},
-			DriverProfile: &models.DriverProfileModel{
-				Id:           gofakeit.UUID(),
+			Profile: &DriverProfileData{
+				Id:           "c2e8a4f6b0d24e8a9c1f3b5d7e9a1c3e",
 				CheckMessage: "great driver",
 				Comment:      "great driver",
 				CreatedDate:  "2020-04-23T13:08:05.552+0000",
-				DriverLicense: models.DriverLicense{
+				DriverLicense: DriverLicense{
 					IssueDate:        "2020-10-28",
 					ExpirationDate:   "2050-10-28",
 					Number:           "070236",
@@ -190,7 +200,7 @@ func TestClient_GetDriverProfiles(t *testing.T) {
 				MiddleName:       "Ivanovich",
 				ParkId:           testPark.Id,
 				Phones:           []string{"+79999999999"},
-				WorkRuleId:       gofakeit.UUID(),
+				WorkRuleId:       "e4a2c0b8d6f44e2a8b6c4d2e0f8a6b4c",
 				WorkStatus:       "working",
 			},
 		}
@@ -204,19 +214,70 @@ func TestClient_GetDriverProfiles(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, args.Limit, req.Limit)
 			require.Equal(t, args.Offset, req.Offset)
-			require.Equal(t, args.ParkId, req.Query.Park.Id)
+			require.Equal(t, string(args.ParkID), req.Query.Park.Id)
 			require.Equal(t, args.QueryText, req.Query.Text)
 
 			w.WriteHeader(http.StatusOK)
-			moqResult := models.DriverProfilesResponse{
-				Total:          6543,
-				Offset:         req.Offset,
-				Limit:          req.Limit,
-				DriverProfiles: []models.DriverProfile{testProfile},
-				Parks:          []models.DriverProfilePark{testPark},
-			}
-			bytes, _ := json.Marshal(moqResult)
-			_, err = w.Write(bytes)
+			_, err = w.Write([]byte(`{
+				"total": 6543,
+				"offset": 5,
+				"limit": 1000,
+				"driver_profiles": [{
+					"accounts": [{
+						"id": "7f1c0e6e2b4f4c1a9d5e3b2a1c0d9e8f",
+						"balance": "1000.00",
+						"balance_limit": "50.00",
+						"currency": "RUB",
+						"type": "current"
+					}],
+					"car": {
+						"id": "5a8d1f3c9e7b4d2a8c6e4f2a0b9d7c5e",
+						"amenities": ["conditioner"],
+						"brand": "Mercedes-Benz",
+						"callsign": "123456789",
+						"category": ["comfort_plus"],
+						"color": "Черный",
+						"model": "E-klasse",
+						"number": "Т8654Т99",
+						"registration_cert": "123456789",
+						"status": "working",
+						"vin": "12345678909876543",
+						"year": 2019
+					},
+					"current_status": {
+						"status": "busy",
+						"status_updated_at": "2020-04-27T08:44:05.871+0000"
+					},
+					"driver_profile": {
+						"id": "c2e8a4f6b0d24e8a9c1f3b5d7e9a1c3e",
+						"check_message": "great driver",
+						"comment": "great driver",
+						"created_date": "2020-04-23T13:08:05.552+0000",
+						"driver_license": {
+							"issue_date": "2020-10-28",
+							"expiration_date": "2050-10-28",
+							"number": "070236",
+							"normalized_number": "AA00123456",
+							"country": "rus",
+							"birth_date": "1975-10-28"
+						},
+						"employment_type": "selfemployed",
+						"first_name": "Ivan",
+						"has_contract_issue": true,
+						"last_name": "Ivanov",
+						"middle_name": "Ivanovich",
+						"park_id": "9b7d5f3a1c8e4a6b2d0f8e6c4a2b0d9f",
+						"phones": ["+79999999999"],
+						"work_rule_id": "e4a2c0b8d6f44e2a8b6c4d2e0f8a6b4c",
+						"work_status": "working"
+					}
+				}],
+				"parks": [{
+					"id": "9b7d5f3a1c8e4a6b2d0f8e6c4a2b0d9f",
+					"city": "Москва",
+					"name": "Таксопарк"
+				}]
+			}`))
 			require.NoError(t, err)
 		}))
 
@@ -231,25 +292,25 @@ func TestClient_GetDriverProfiles(t *testing.T) {
 		require.NotNil(t, result)
 
 		require.Equal(t, 1, len(result.DriverProfiles))
-		require.Equal(t, testProfile.DriverProfile.Id, result.DriverProfiles[0].Profile.Id)
-		require.Equal(t, testProfile.DriverProfile.CheckMessage, result.DriverProfiles[0].Profile.CheckMessage)
-		require.Equal(t, testProfile.DriverProfile.Comment, result.DriverProfiles[0].Profile.Comment)
-		require.Equal(t, testProfile.DriverProfile.CreatedDate, result.DriverProfiles[0].Profile.CreatedDate)
-		require.Equal(t, testProfile.DriverProfile.DriverLicense.IssueDate, result.DriverProfiles[0].Profile.DriverLicense.IssueDate)
-		require.Equal(t, testProfile.DriverProfile.DriverLicense.ExpirationDate, result.DriverProfiles[0].Profile.DriverLicense.ExpirationDate)
-		require.Equal(t, testProfile.DriverProfile.DriverLicense.Number, result.DriverProfiles[0].Profile.DriverLicense.Number)
-		require.Equal(t, testProfile.DriverProfile.DriverLicense.NormalizedNumber, result.DriverProfiles[0].Profile.DriverLicense.NormalizedNumber)
-		require.Equal(t, testProfile.DriverProfile.DriverLicense.Country, result.DriverProfiles[0].Profile.DriverLicense.Country)
-		require.Equal(t, testProfile.DriverProfile.DriverLicense.BirthDate, result.DriverProfiles[0].Profile.DriverLicense.BirthDate)
-		require.Equal(t, testProfile.DriverProfile.EmploymentType, result.DriverProfiles[0].Profile.EmploymentType)
-		require.Equal(t, testProfile.DriverProfile.FirstName, result.DriverProfiles[0].Profile.FirstName)
-		require.Equal(t, testProfile.DriverProfile.HasContractIssue, result.DriverProfiles[0].Profile.HasContractIssue)
-		require.Equal(t, testProfile.DriverProfile.LastName, result.DriverProfiles[0].Profile.LastName)
-		require.Equal(t, testProfile.DriverProfile.MiddleName, result.DriverProfiles[0].Profile.MiddleName)
-		require.Equal(t, testProfile.DriverProfile.ParkId, result.DriverProfiles[0].Profile.ParkId)
-		require.Equal(t, testProfile.DriverProfile.Phones, result.DriverProfiles[0].Profile.Phones)
-		require.Equal(t, testProfile.DriverProfile.WorkRuleId, result.DriverProfiles[0].Profile.WorkRuleId)
-		require.Equal(t, testProfile.DriverProfile.WorkStatus, result.DriverProfiles[0].Profile.WorkStatus)
+		require.Equal(t, testProfile.Profile.Id, result.DriverProfiles[0].Profile.Id)
+		require.Equal(t, testProfile.Profile.CheckMessage, result.DriverProfiles[0].Profile.CheckMessage)
+		require.Equal(t, testProfile.Profile.Comment, result.DriverProfiles[0].Profile.Comment)
+		require.Equal(t, testProfile.Profile.CreatedDate, result.DriverProfiles[0].Profile.CreatedDate)
+		require.Equal(t, testProfile.Profile.DriverLicense.IssueDate, result.DriverProfiles[0].Profile.DriverLicense.IssueDate)
+		require.Equal(t, testProfile.Profile.DriverLicense.ExpirationDate, result.DriverProfiles[0].Profile.DriverLicense.ExpirationDate)
+		require.Equal(t, testProfile.Profile.DriverLicense.Number, result.DriverProfiles[0].Profile.DriverLicense.Number)
+		require.Equal(t, testProfile.Profile.DriverLicense.NormalizedNumber, result.DriverProfiles[0].Profile.DriverLicense.NormalizedNumber)
+		require.Equal(t, testProfile.Profile.DriverLicense.Country, result.DriverProfiles[0].Profile.DriverLicense.Country)
+		require.Equal(t, testProfile.Profile.DriverLicense.BirthDate, result.DriverProfiles[0].Profile.DriverLicense.BirthDate)
+		require.Equal(t, testProfile.Profile.EmploymentType, result.DriverProfiles[0].Profile.EmploymentType)
+		require.Equal(t, testProfile.Profile.FirstName, result.DriverProfiles[0].Profile.FirstName)
+		require.Equal(t, testProfile.Profile.HasContractIssue, result.DriverProfiles[0].Profile.HasContractIssue)
+		require.Equal(t, testProfile.Profile.LastName, result.DriverProfiles[0].Profile.LastName)
+		require.Equal(t, testProfile.Profile.MiddleName, result.DriverProfiles[0].Profile.MiddleName)
+		require.Equal(t, testProfile.Profile.ParkId, result.DriverProfiles[0].Profile.ParkId)
+		require.Equal(t, testProfile.Profile.Phones, result.DriverProfiles[0].Profile.Phones)
+		require.Equal(t, testProfile.Profile.WorkRuleId, result.DriverProfiles[0].Profile.WorkRuleId)
+		require.Equal(t, testProfile.Profile.WorkStatus, result.DriverProfiles[0].Profile.WorkStatus)
 		require.Equal(t, testProfile.CurrentStatus.Status, result.DriverProfiles[0].CurrentStatus.Status)
 		require.Equal(t, testProfile.CurrentStatus.StatusUpdatedAt, result.DriverProfiles[0].CurrentStatus.StatusUpdatedAt)
 		require.Equal(t, testProfile.Car.Id, result.DriverProfiles[0].Car.Id)
@@ -283,7 +344,7 @@ func TestClient_GetDriverProfiles(t *testing.T) {
 		args := GetDriverProfilesArgs{
 			Offset:    5,
 			Limit:     1000,
-			ParkId:    "park-id",
+			ParkID:    "park-id",
 			QueryText: "some query text",
 		}
 
@@ -296,7 +357,7 @@ func TestClient_GetDriverProfiles(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, args.Limit, req.Limit)
 			require.Equal(t, args.Offset, req.Offset)
-			require.Equal(t, args.ParkId, req.Query.Park.Id)
+			require.Equal(t, string(args.ParkID), req.Query.Park.Id)
 			require.Equal(t, args.QueryText, req.Query.Text)
 
 			w.WriteHeader(http.StatusBadRequest)
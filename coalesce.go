@@ -0,0 +1,124 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// WithRequestCoalescing Объединяет одновременные одинаковые запросы: вызовы с тем же методом API,
+// данными авторизации, языком и телом запроса ожидают один HTTP-запрос. Каждый вызов декодирует
+// ответ в собственную копию результата. Отмена контекста вызова прекращает только его ожидание;
+// общий запрос отменяется, когда его перестают ожидать все вызовы. Параметры вызова (таймаут,
+// заголовки) берутся у вызова, начавшего запрос. Потоковые методы запросы не объединяют
+func WithRequestCoalescing() func(client *Client) {
+	return func(s *Client) {
+		s.flights = &flightGroup{calls: make(map[string]*flight)}
+	}
+}
+
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+type flight struct {
+	done   chan struct{}
+	body   []byte
+	err    error
+	refs   int
+	cancel context.CancelFunc
+}
+
+// fetchBody Выполняет запрос и возвращает тело успешного ответа. Признак shared означает,
+// что ответ получен запросом, начатым другим вызовом
+func (c *Client) fetchBody(ctx context.Context, info CallInfo, reqData any, opts []CallOption) (body []byte, shared bool, err error) {
+	if c.flights != nil {
+		return c.coalesced(ctx, info, reqData, opts)
+	}
+
+	body, err = c.readBody(ctx, info, reqData, opts)
+	return body, false, err
+}
+
+func (c *Client) readBody(ctx context.Context, info CallInfo, reqData any, opts []CallOption) ([]byte, error) {
+	res, err := c.send(ctx, info, reqData, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return io.ReadAll(res.Body)
+}
+
+func (c *Client) coalesced(ctx context.Context, info CallInfo, reqData any, opts []CallOption) ([]byte, bool, error) {
+	key, err := c.requestKey(ctx, info, reqData, opts)
+	if err != nil {
+		return nil, false, err
+	}
+
+	g := c.flights
+	g.mu.Lock()
+	f, shared := g.calls[key]
+	if !shared {
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = f
+
+		go func() {
+			f.body, f.err = c.readBody(flightCtx, info, reqData, opts)
+
+			g.mu.Lock()
+			if g.calls[key] == f {
+				delete(g.calls, key)
+			}
+			g.mu.Unlock()
+
+			cancel()
+			close(f.done)
+		}()
+	}
+	f.refs++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.body, shared, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.refs--
+		if f.refs == 0 {
+			f.cancel()
+			if g.calls[key] == f {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, shared, ctx.Err()
+	}
+}
+
+// requestKey Ключ кэша и объединения запросов: путь метода, данные авторизации, язык ответа и тело
+// запроса. Ключ API входит в ключ в виде хеша, чтобы ответ, полученный с одними данными авторизации,
+// не достался клиенту с другими через общее хранилище
+func (c *Client) requestKey(ctx context.Context, info CallInfo, reqData any, opts []CallOption) (string, error) {
+	creds, err := c.credentials.Credentials(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(reqData)
+	if err != nil {
+		return "", err
+	}
+
+	apiKey := sha256.Sum256([]byte(creds.APIKey))
+	return info.Endpoint + "\n" +
+		creds.ClientID + "\n" +
+		hex.EncodeToString(apiKey[:]) + "\n" +
+		c.callOptions(opts).language + "\n" +
+		string(body), nil
+}
@@ -0,0 +1,183 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/sinland/yandex-taxi-go/internal/models"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type blockingServer struct {
+	url      string
+	release  chan struct{}
+	requests atomic.Int32
+	canceled atomic.Int32
+}
+
+func newBlockingServer(t *testing.T) *blockingServer {
+	s := &blockingServer{release: make(chan struct{})}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+
+		var req models.DriverProfilesRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		select {
+		case <-s.release:
+		case <-r.Context().Done():
+			s.canceled.Add(1)
+			return
+		}
+
+		bytes, _ := json.Marshal(GetDriverProfilesResult{
+			Total:          1,
+			DriverProfiles: []DriverProfile{{Profile: &DriverProfileData{Id: "driver-id", ParkId: ParkID(req.Query.Park.Id)}}},
+		})
+		_, _ = w.Write(bytes)
+	}))
+	t.Cleanup(server.Close)
+	s.url = server.URL
+	return s
+}
+
+// waitFlight Ожидает, пока общий запрос будут ожидать refs вызовов
+func waitFlight(t *testing.T, c *Client, refs int) {
+	require.Eventually(t, func() bool {
+		c.flights.mu.Lock()
+		defer c.flights.mu.Unlock()
+
+		total := 0
+		for _, f := range c.flights.calls {
+			total += f.refs
+		}
+		return total == refs
+	}, time.Second, time.Millisecond)
+}
+
+func TestClient_RequestCoalescing(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("concurrent identical calls share one request", func(t *testing.T) {
+		t.Parallel()
+
+		s := newBlockingServer(t)
+		c := NewClient(ClientConfig{ClientID: testClientID, APIKey: testAPIKey}, WithAPIHost(s.url), WithRequestCoalescing())
+
+		const callers = 5
+		results := make([]*GetDriverProfilesResult, callers)
+		var wg sync.WaitGroup
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				res, err := c.GetDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-1"})
+				require.NoError(t, err)
+				results[i] = res
+			}(i)
+		}
+
+		waitFlight(t, c, callers)
+		close(s.release)
+		wg.Wait()
+
+		require.Equal(t, int32(1), s.requests.Load())
+
+		shared := 0
+		for _, res := range results {
+			if res.Meta.Shared {
+				shared++
+			}
+		}
+		require.Equal(t, callers-1, shared)
+
+		results[0].DriverProfiles[0].Profile.Id = "changed"
+		require.Equal(t, DriverID("driver-id"), results[1].DriverProfiles[0].Profile.Id)
+	})
+
+	t.Run("different parks and credentials are not coalesced", func(t *testing.T) {
+		t.Parallel()
+
+		s := newBlockingServer(t)
+		close(s.release)
+
+		c := NewClient(ClientConfig{ClientID: testClientID, APIKey: testAPIKey}, WithAPIHost(s.url), WithRequestCoalescing())
+		other := NewClient(ClientConfig{ClientID: testClientID, APIKey: "other"}, WithAPIHost(s.url), WithRequestCoalescing())
+
+		_, err := c.GetDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-1"})
+		require.NoError(t, err)
+		_, err = c.GetDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-2"})
+		require.NoError(t, err)
+
+		key1, err := c.requestKey(ctx, CallInfo{Endpoint: EndpointDriverProfilesList}, "body", nil)
+		require.NoError(t, err)
+		key2, err := other.requestKey(ctx, CallInfo{Endpoint: EndpointDriverProfilesList}, "body", nil)
+		require.NoError(t, err)
+
+		require.NotEqual(t, key1, key2)
+		require.NotContains(t, key2, "other")
+		require.Equal(t, int32(2), s.requests.Load())
+	})
+
+	t.Run("canceled caller does not cancel others", func(t *testing.T) {
+		t.Parallel()
+
+		s := newBlockingServer(t)
+		c := NewClient(ClientConfig{ClientID: testClientID, APIKey: testAPIKey}, WithAPIHost(s.url), WithRequestCoalescing())
+
+		firstCtx, cancelFirst := context.WithCancel(ctx)
+		defer cancelFirst()
+
+		firstErr := make(chan error, 1)
+		go func() {
+			_, err := c.GetDriverProfiles(firstCtx, GetDriverProfilesArgs{ParkID: "park-1"})
+			firstErr <- err
+		}()
+		waitFlight(t, c, 1)
+
+		second := make(chan *GetDriverProfilesResult, 1)
+		go func() {
+			res, err := c.GetDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-1"})
+			require.NoError(t, err)
+			second <- res
+		}()
+		waitFlight(t, c, 2)
+
+		cancelFirst()
+		require.ErrorIs(t, <-firstErr, context.Canceled)
+		waitFlight(t, c, 1)
+
+		close(s.release)
+		res := <-second
+		require.Equal(t, 1, res.Total)
+		require.True(t, res.Meta.Shared)
+		require.Equal(t, int32(1), s.requests.Load())
+		require.Equal(t, int32(0), s.canceled.Load())
+	})
+
+	t.Run("request canceled when all callers leave", func(t *testing.T) {
+		t.Parallel()
+
+		s := newBlockingServer(t)
+		c := NewClient(ClientConfig{ClientID: testClientID, APIKey: testAPIKey}, WithAPIHost(s.url), WithRequestCoalescing())
+
+		callCtx, cancel := context.WithCancel(ctx)
+		done := make(chan error, 1)
+		go func() {
+			_, err := c.GetDriverProfiles(callCtx, GetDriverProfilesArgs{ParkID: "park-1"})
+			done <- err
+		}()
+		waitFlight(t, c, 1)
+
+		cancel()
+		require.ErrorIs(t, <-done, context.Canceled)
+		require.Eventually(t, func() bool { return s.canceled.Load() == 1 }, time.Second, time.Millisecond)
+	})
+}
@@ -0,0 +1,203 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Credentials Данные авторизации в API
+type Credentials struct {
+	ClientID string `json:"client_id"` // Идентификатор клиента (заголовок X-Client-ID)
+	APIKey   string `json:"api_key"`   // Ключ API (заголовок X-API-Key)
+}
+
+// CredentialsProvider Источник данных авторизации. Клиент запрашивает данные перед каждым запросом,
+// а при ответе 401 один раз вызывает Refresh и повторяет запрос
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+	Refresh(ctx context.Context) error
+}
+
+// WithCredentialsProvider Источник данных авторизации. Заменяет ClientID и APIKey из ClientConfig
+func WithCredentialsProvider(provider CredentialsProvider) func(client *Client) {
+	return func(s *Client) {
+		s.credentials = provider
+	}
+}
+
+// credentialsMiddleware Подставляет в запрос данные авторизации. При ответе 401 обновляет их
+// и один раз повторяет запрос
+func (c *Client) credentialsMiddleware(next RoundTrip) RoundTrip {
+	return func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+
+		for refreshed := false; ; refreshed = true {
+			creds, err := c.credentials.Credentials(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("get credentials: %w", err)
+			}
+
+			attemptReq := req.Clone(ctx)
+			if refreshed && req.GetBody != nil {
+				if attemptReq.Body, err = req.GetBody(); err != nil {
+					return nil, err
+				}
+			}
+			attemptReq.Header.Set(headerXAPIKey, creds.APIKey)
+			attemptReq.Header.Set(headerXCientID, creds.ClientID)
+
+			res, err := next(attemptReq)
+			if err != nil || res.StatusCode != http.StatusUnauthorized || refreshed || (req.Body != nil && req.GetBody == nil) {
+				return res, err
+			}
+
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+
+			if err = c.credentials.Refresh(ctx); err != nil {
+				return nil, fmt.Errorf("refresh credentials: %w", err)
+			}
+		}
+	}
+}
+
+// StaticCredentials Неизменяемые данные авторизации
+type StaticCredentials Credentials
+
+// NewStaticCredentials Создает источник с неизменяемыми данными авторизации
+func NewStaticCredentials(clientID, apiKey string) StaticCredentials {
+	return StaticCredentials{ClientID: clientID, APIKey: apiKey}
+}
+
+func (s StaticCredentials) Credentials(context.Context) (Credentials, error) {
+	return Credentials(s), nil
+}
+
+func (s StaticCredentials) Refresh(context.Context) error {
+	return nil
+}
+
+// EnvCredentials Данные авторизации из переменных окружения. Значения читаются при каждом запросе
+type EnvCredentials struct {
+	ClientIDVar string // Имя переменной с идентификатором клиента
+	APIKeyVar   string // Имя переменной с ключом API
+}
+
+// NewEnvCredentials Создает источник, читающий данные авторизации из переменных окружения
+func NewEnvCredentials(clientIDVar, apiKeyVar string) EnvCredentials {
+	return EnvCredentials{ClientIDVar: clientIDVar, APIKeyVar: apiKeyVar}
+}
+
+func (e EnvCredentials) Credentials(context.Context) (Credentials, error) {
+	creds := Credentials{
+		ClientID: os.Getenv(e.ClientIDVar),
+		APIKey:   os.Getenv(e.APIKeyVar),
+	}
+	if creds.ClientID == "" || creds.APIKey == "" {
+		return Credentials{}, fmt.Errorf("environment variables %s and %s must be set", e.ClientIDVar, e.APIKeyVar)
+	}
+	return creds, nil
+}
+
+func (e EnvCredentials) Refresh(context.Context) error {
+	return nil
+}
+
+// FileCredentials Данные авторизации из JSON-файла вида {"client_id": "...", "api_key": "..."}.
+// Файл перечитывается, если изменились время модификации или размер; проверка выполняется
+// не чаще чем раз в CheckInterval. Ошибки чтения пишутся в журнал slog.Default()
+type FileCredentials struct {
+	path          string
+	checkInterval time.Duration
+
+	mu        sync.Mutex
+	creds     Credentials
+	modTime   time.Time
+	size      int64
+	checkedAt time.Time
+}
+
+// NewFileCredentials Создает источник, читающий данные авторизации из файла path.
+// checkInterval - минимальный интервал между проверками изменения файла
+func NewFileCredentials(path string, checkInterval time.Duration) (*FileCredentials, error) {
+	f := &FileCredentials{
+		path:          path,
+		checkInterval: checkInterval,
+	}
+	if err := f.Refresh(context.Background()); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Credentials Возвращает данные авторизации, при необходимости перечитывая файл. Если файл
+// не удалось перечитать, например он еще записывается, ошибка записывается в журнал, а до следующей
+// проверки используются последние успешно прочитанные данные
+func (f *FileCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if time.Since(f.checkedAt) < f.checkInterval {
+		return f.creds, nil
+	}
+	f.checkedAt = time.Now()
+
+	info, err := os.Stat(f.path)
+	if err == nil && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.creds, nil
+	}
+	if err == nil {
+		err = f.load()
+	}
+	if err != nil {
+		if f.creds == (Credentials{}) {
+			return Credentials{}, err
+		}
+		slog.Default().WarnContext(ctx, "fleet credentials reload failed, using previous credentials",
+			"path", f.path,
+			"error", err,
+		)
+	}
+	return f.creds, nil
+}
+
+// Refresh Принудительно перечитывает файл
+func (f *FileCredentials) Refresh(context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.load()
+}
+
+func (f *FileCredentials) load() error {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+
+	var creds Credentials
+	if err = json.Unmarshal(data, &creds); err != nil {
+		return fmt.Errorf("parse credentials file %s: %w", f.path, err)
+	}
+	if creds.ClientID == "" || creds.APIKey == "" {
+		return errors.New("credentials file must contain client_id and api_key")
+	}
+
+	f.creds = creds
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+	f.checkedAt = time.Now()
+	return nil
+}
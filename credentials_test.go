@@ -0,0 +1,188 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type countingCredentials struct {
+	keys      []string
+	refreshes atomic.Int32
+}
+
+func (c *countingCredentials) Credentials(context.Context) (Credentials, error) {
+	n := int(c.refreshes.Load())
+	if n >= len(c.keys) {
+		n = len(c.keys) - 1
+	}
+	return Credentials{ClientID: testClientID, APIKey: c.keys[n]}, nil
+}
+
+func (c *countingCredentials) Refresh(context.Context) error {
+	c.refreshes.Add(1)
+	return nil
+}
+
+func writeCredentialsFile(t *testing.T, path string, creds Credentials) {
+	data, err := json.Marshal(creds)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func TestClient_CredentialsRefresh(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+	)
+
+	newServer := func(t *testing.T, validKey string) (*httptest.Server, *atomic.Int32) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			if body, _ := io.ReadAll(r.Body); len(body) == 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if r.Header.Get(headerXAPIKey) != validKey {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"code":"unauthorized","message":"Invalid API key"}`))
+				return
+			}
+			bytes, _ := json.Marshal(GetCarsListResult{Total: 1})
+			_, _ = w.Write(bytes)
+		}))
+		t.Cleanup(server.Close)
+		return server, &calls
+	}
+
+	t.Run("refresh and retry once", func(t *testing.T) {
+		t.Parallel()
+
+		server, calls := newServer(t, "new-key")
+		creds := &countingCredentials{keys: []string{"old-key", "new-key"}}
+
+		var observed atomic.Int32
+		observe := func(next RoundTrip) RoundTrip {
+			return func(req *http.Request) (*http.Response, error) {
+				observed.Add(1)
+				return next(req)
+			}
+		}
+
+		c := NewClient(ClientConfig{}, WithAPIHost(server.URL), WithCredentialsProvider(creds), WithMiddleware(observe))
+
+		result, err := c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-id"})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Total)
+		require.Equal(t, int32(1), creds.refreshes.Load())
+		require.Equal(t, int32(2), calls.Load())
+		require.Equal(t, int32(1), observed.Load(), "user middleware must see a single call")
+	})
+
+	t.Run("second 401 is returned", func(t *testing.T) {
+		t.Parallel()
+
+		server, calls := newServer(t, "valid-key")
+		creds := &countingCredentials{keys: []string{"old-key", "still-old-key", "valid-key"}}
+
+		c := NewClient(ClientConfig{}, WithAPIHost(server.URL), WithCredentialsProvider(creds))
+
+		_, err := c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-id"})
+		require.Error(t, err)
+		require.Equal(t, "[401] Invalid API key (unauthorized)", err.Error())
+		require.Equal(t, int32(1), creds.refreshes.Load())
+		require.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("file rotation", func(t *testing.T) {
+		t.Parallel()
+
+		server, _ := newServer(t, "rotated-key")
+		path := filepath.Join(t.TempDir(), "credentials.json")
+		writeCredentialsFile(t, path, Credentials{ClientID: testClientID, APIKey: "leaked-key"})
+
+		creds, err := NewFileCredentials(path, time.Hour)
+		require.NoError(t, err)
+
+		c := NewClient(ClientConfig{}, WithAPIHost(server.URL), WithCredentialsProvider(creds))
+
+		_, err = c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-id"})
+		require.Error(t, err)
+
+		writeCredentialsFile(t, path, Credentials{ClientID: testClientID, APIKey: "rotated-key"})
+
+		_, err = c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-id"})
+		require.NoError(t, err)
+	})
+}
+
+func TestFileCredentials(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	writeCredentialsFile(t, path, Credentials{ClientID: "client-1", APIKey: "key-1"})
+
+	creds, err := NewFileCredentials(path, 0)
+	require.NoError(t, err)
+
+	got, err := creds.Credentials(ctx)
+	require.NoError(t, err)
+	require.Equal(t, Credentials{ClientID: "client-1", APIKey: "key-1"}, got)
+
+	writeCredentialsFile(t, path, Credentials{ClientID: "client-1", APIKey: "key-2-longer"})
+
+	got, err = creds.Credentials(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "key-2-longer", got.APIKey)
+
+	// Файл, который еще записывается, не должен прерывать запросы
+	require.NoError(t, os.WriteFile(path, []byte(`{"client_id": "client-1", "api`), 0o600))
+	got, err = creds.Credentials(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "key-2-longer", got.APIKey)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"client_id": "client-1"}`), 0o600))
+	got, err = creds.Credentials(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "key-2-longer", got.APIKey)
+	require.Error(t, creds.Refresh(ctx))
+
+	require.NoError(t, os.Remove(path))
+	got, err = creds.Credentials(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "key-2-longer", got.APIKey)
+
+	writeCredentialsFile(t, path, Credentials{ClientID: "client-1", APIKey: "key-3"})
+	got, err = creds.Credentials(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "key-3", got.APIKey)
+
+	_, err = NewFileCredentials(filepath.Join(t.TempDir(), "missing.json"), 0)
+	require.Error(t, err)
+}
+
+func TestEnvCredentials(t *testing.T) {
+	ctx := context.Background()
+	creds := NewEnvCredentials("TEST_FLEET_CLIENT_ID", "TEST_FLEET_API_KEY")
+
+	_, err := creds.Credentials(ctx)
+	require.Error(t, err)
+
+	t.Setenv("TEST_FLEET_CLIENT_ID", "client-1")
+	t.Setenv("TEST_FLEET_API_KEY", "key-1")
+
+	got, err := creds.Credentials(ctx)
+	require.NoError(t, err)
+	require.Equal(t, Credentials{ClientID: "client-1", APIKey: "key-1"}, got)
+}
@@ -0,0 +1,78 @@
+package yandex_taxi_go
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// FieldChange Изменение одного поля. Field - путь к полю в формате API через точку, например "car.number".
+// Old и New содержат значения в JSON-представлении: строки, числа, bool, массивы или nil
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// diffFields Сравнивает JSON-представления old и new по полям. Массивы сравниваются целиком.
+// Поля из ignore не сравниваются. Результат отсортирован по пути поля
+func diffFields(old, new any, ignore ...string) ([]FieldChange, error) {
+	oldFields, err := flattenJSON(old)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := flattenJSON(new)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []FieldChange
+	for field, newValue := range newFields {
+		if oldValue := oldFields[field]; !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+	for field, oldValue := range oldFields {
+		if _, ok := newFields[field]; !ok && oldValue != nil {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue})
+		}
+	}
+
+	changes = slices.DeleteFunc(changes, func(c FieldChange) bool { return slices.Contains(ignore, c.Field) })
+	slices.SortFunc(changes, func(a, b FieldChange) int { return strings.Compare(a.Field, b.Field) })
+	return changes, nil
+}
+
+func flattenJSON(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var root any
+	if err = json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]any)
+	flattenValue("", root, fields)
+	return fields, nil
+}
+
+func flattenValue(prefix string, v any, fields map[string]any) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		if prefix != "" {
+			fields[prefix] = v
+		}
+		return
+	}
+
+	for key, value := range obj {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		flattenValue(key, value, fields)
+	}
+}
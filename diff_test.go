@@ -0,0 +1,65 @@
+package yandex_taxi_go
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDiffFields(t *testing.T) {
+	t.Parallel()
+
+	old := DriverProfile{
+		Profile:   &DriverProfileData{Id: "driver-1", FirstName: "Иван", Phones: []string{"+79990000001"}},
+		UpdatedAt: "2024-01-01T00:00:00Z",
+	}
+	updated := DriverProfile{
+		Car:       &Vehicle{Id: "car-1", Number: "А001АА77"},
+		Profile:   &DriverProfileData{Id: "driver-1", FirstName: "Пётр", Phones: []string{"+79990000001"}},
+		UpdatedAt: "2024-01-02T00:00:00Z",
+	}
+
+	changes, err := diffFields(old, updated, "updated_at")
+	require.NoError(t, err)
+	require.Equal(t, []FieldChange{
+		{Field: "car.id", New: "car-1"},
+		{Field: "car.number", New: "А001АА77"},
+		{Field: "driver_profile.first_name", Old: "Иван", New: "Пётр"},
+	}, filterFields(changes, "car.id", "car.number", "driver_profile.first_name"))
+	require.Len(t, changes, 11)
+
+	changes, err = diffFields(updated, old, "updated_at")
+	require.NoError(t, err)
+	require.Equal(t, []FieldChange{{Field: "car.id", Old: "car-1"}}, filterFields(changes, "car.id"))
+
+	changes, err = diffFields(old, old)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func filterFields(changes []FieldChange, fields ...string) []FieldChange {
+	var out []FieldChange
+	for _, c := range changes {
+		for _, f := range fields {
+			if c.Field == f {
+				out = append(out, c)
+			}
+		}
+	}
+	return out
+}
+
+func TestParseTime(t *testing.T) {
+	t.Parallel()
+
+	want := time.Date(2020, time.April, 27, 8, 44, 5, 871000000, time.UTC)
+
+	for _, value := range []string{"2020-04-27T08:44:05.871+0000", "2020-04-27T08:44:05.871Z", "2020-04-27T11:44:05.871+03:00"} {
+		got, err := ParseTime(value)
+		require.NoError(t, err, value)
+		require.True(t, want.Equal(got), value)
+	}
+
+	_, err := ParseTime("27.04.2020")
+	require.EqualError(t, err, `invalid time "27.04.2020"`)
+}
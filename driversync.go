@@ -0,0 +1,251 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSyncInterval  = time.Minute
+	defaultDriverOverlap = time.Minute
+)
+
+// driverSyncSortOrder Порядок, в котором DriverSync читает профили
+var driverSyncSortOrder = []DriverProfileSortOrder{
+	{Field: "driver_profile.updated_at", Direction: "asc"},
+}
+
+// DriverEventType Тип события синхронизации профилей водителей
+type DriverEventType string
+
+const (
+	DriverCreated DriverEventType = "created" // Профиль встретился впервые
+	DriverUpdated DriverEventType = "updated" // Известный профиль изменился
+)
+
+// DriverEvent Изменение профиля водителя, обнаруженное DriverSync
+type DriverEvent struct {
+	Type      DriverEventType
+	ParkID    ParkID
+	DriverID  DriverID
+	UpdatedAt time.Time      // Время обновления профиля по данным API
+	Profile   DriverProfile  // Текущее состояние профиля
+	Previous  *DriverProfile // Предыдущее известное состояние. nil для DriverCreated
+	Changes   []FieldChange  // Измененные поля. Для DriverCreated не заполняется
+}
+
+// DriverStateStore Последние известные состояния профилей водителей, по которым DriverSync вычисляет изменения
+type DriverStateStore interface {
+	// Get Возвращает состояние профиля по идентификатору
+	Get(ctx context.Context, parkID ParkID, id DriverID) (DriverProfile, bool, error)
+	// Put Сохраняет состояние профиля
+	Put(ctx context.Context, parkID ParkID, profile DriverProfile) error
+}
+
+// DriverSyncConfig Параметры DriverSync
+type DriverSyncConfig struct {
+	ParkID      ParkID
+	Checkpoints CheckpointStore  // Хранилище отметки синхронизации. По умолчанию в памяти
+	State       DriverStateStore // Хранилище известных профилей. По умолчанию в памяти; обязательно, если Checkpoints хранит отметку вне памяти
+	Overlap     time.Duration    // Насколько раньше отметки начинать следующий запрос, чтобы не пропустить запоздавшие обновления. По умолчанию одна минута
+	PageLimit   int              // Размер страницы. По умолчанию 1000
+	Interval    time.Duration    // Период опроса в Run. По умолчанию и при отрицательном значении одна минута
+}
+
+// DriverSync Инкрементальная синхронизация профилей водителей парка по времени последнего обновления.
+// Каждый проход запрашивает профили, обновленные начиная с сохраненной отметки, сравнивает их
+// с известными состояниями и передает обработчику события с изменениями по полям. Отметка
+// сохраняется после успешной обработки всего прохода, поэтому после сбоя проход повторяется;
+// уже обработанные профили совпадут с сохраненным состоянием и повторных событий не дадут
+type DriverSync struct {
+	client *Client
+	cfg    DriverSyncConfig
+	mu     sync.Mutex
+}
+
+// NewDriverSync Создает синхронизацию профилей водителей парка cfg.ParkID. Если отметка хранится
+// вне памяти, cfg.State обязателен: иначе после перезапуска известные профили были бы потеряны,
+// и все профили с отметки пришли бы обработчику как DriverCreated
+func NewDriverSync(client *Client, cfg DriverSyncConfig) (*DriverSync, error) {
+	if cfg.State == nil {
+		if _, inMemory := cfg.Checkpoints.(*MemoryCheckpointStore); cfg.Checkpoints != nil && !inMemory {
+			return nil, errors.New("driver sync: State is required when Checkpoints is persistent")
+		}
+		cfg.State = NewMemoryDriverState()
+	}
+	if cfg.Checkpoints == nil {
+		cfg.Checkpoints = NewMemoryCheckpointStore()
+	}
+	if cfg.Overlap == 0 {
+		cfg.Overlap = defaultDriverOverlap
+	}
+	if cfg.PageLimit == 0 {
+		cfg.PageLimit = defaultPageLimit
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultSyncInterval
+	}
+
+	return &DriverSync{client: client, cfg: cfg}, nil
+}
+
+func (s *DriverSync) checkpointKey() string {
+	return "drivers/" + string(s.cfg.ParkID)
+}
+
+// Sync Выполняет один проход синхронизации и возвращает число переданных обработчику событий.
+// События передаются постранично, по мере чтения профилей. Ошибка обработчика прерывает проход
+// без сохранения отметки
+func (s *DriverSync) Sync(ctx context.Context, fn func(DriverEvent) error) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Без отметки запрашиваются все профили
+	cp, from, err := startCursorSyncPass(ctx, s.cfg.Checkpoints, s.checkpointKey(), time.Unix(0, 0), time.Now(), s.cfg.Overlap)
+	if err != nil {
+		return 0, err
+	}
+
+	// Профили читаются по возрастанию времени обновления, и каждая следующая страница начинается со времени
+	// последнего прочитанного профиля. Профиль, обновленный во время прохода, уходит в конец списка
+	// и не сдвигает непрочитанные строки. Профили на границе страниц читаются повторно и событий
+	// не дают, так как совпадают с сохраненным состоянием. Offset используется, только если вся
+	// страница состоит из профилей с одним временем
+	args := GetDriverProfilesArgs{
+		ParkID:    s.cfg.ParkID,
+		Limit:     s.cfg.PageLimit,
+		UpdatedAt: &TimeRange{From: from},
+		SortOrder: driverSyncSortOrder,
+	}
+
+	events := 0
+	for {
+		page, err := s.client.GetDriverProfiles(ctx, args)
+		if err != nil {
+			return events, err
+		}
+
+		var last time.Time
+		for _, p := range page.DriverProfiles {
+			if p.Profile == nil {
+				continue
+			}
+			updatedAt, err := ParseTime(p.UpdatedAt)
+			if err != nil {
+				return events, fmt.Errorf("driver %s: updated_at: %w", p.Profile.Id, err)
+			}
+
+			event, changed, err := s.event(ctx, p, updatedAt)
+			if err != nil {
+				return events, err
+			}
+			if changed {
+				if err = fn(event); err != nil {
+					return events, err
+				}
+				events++
+			}
+			if err = s.cfg.State.Put(ctx, s.cfg.ParkID, p); err != nil {
+				return events, fmt.Errorf("save driver %s: %w", p.Profile.Id, err)
+			}
+			cp.advance(updatedAt)
+			last = updatedAt
+		}
+
+		if len(page.DriverProfiles) == 0 || args.Offset+len(page.DriverProfiles) >= page.Total {
+			break
+		}
+		if last.IsZero() || last.Equal(args.UpdatedAt.From) {
+			args.Offset += len(page.DriverProfiles)
+		} else {
+			args.UpdatedAt = &TimeRange{From: last}
+			args.Offset = 0
+		}
+	}
+
+	return events, cp.commit(ctx, TimeRange{}, "")
+}
+
+// event Сравнивает профиль с известным состоянием. changed равен false, если профиль не изменился
+func (s *DriverSync) event(ctx context.Context, profile DriverProfile, updatedAt time.Time) (event DriverEvent, changed bool, err error) {
+	event = DriverEvent{
+		Type:      DriverCreated,
+		ParkID:    s.cfg.ParkID,
+		DriverID:  profile.Profile.Id,
+		UpdatedAt: updatedAt,
+		Profile:   profile,
+	}
+
+	previous, found, err := s.cfg.State.Get(ctx, s.cfg.ParkID, profile.Profile.Id)
+	if err != nil {
+		return event, false, fmt.Errorf("load driver %s: %w", profile.Profile.Id, err)
+	}
+	if !found {
+		return event, true, nil
+	}
+
+	changes, err := diffFields(previous, profile, "updated_at")
+	if err != nil {
+		return event, false, err
+	}
+
+	event.Type = DriverUpdated
+	event.Previous = &previous
+	event.Changes = changes
+	return event, len(changes) > 0, nil
+}
+
+// Run Выполняет Sync раз в cfg.Interval до отмены ctx и возвращает ошибку контекста.
+// Ошибки отдельных проходов только записываются в журнал клиента
+func (s *DriverSync) Run(ctx context.Context, fn func(DriverEvent) error) error {
+	return s.client.runEvery(ctx, s.cfg.Interval, "fleet driver sync failed", s.cfg.ParkID, func(ctx context.Context) error {
+		_, err := s.Sync(ctx, fn)
+		return err
+	})
+}
+
+// MemoryDriverState Хранилище известных профилей водителей в памяти
+type MemoryDriverState struct {
+	mu       sync.Mutex
+	profiles map[ParkID]map[DriverID]DriverProfile
+}
+
+// NewMemoryDriverState Создает хранилище профилей в памяти
+func NewMemoryDriverState() *MemoryDriverState {
+	return &MemoryDriverState{profiles: make(map[ParkID]map[DriverID]DriverProfile)}
+}
+
+// Get Возвращает состояние профиля
+func (s *MemoryDriverState) Get(_ context.Context, parkID ParkID, id DriverID) (DriverProfile, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.profiles[parkID][id]
+	return p, ok, nil
+}
+
+// Put Сохраняет состояние профиля
+func (s *MemoryDriverState) Put(_ context.Context, parkID ParkID, profile DriverProfile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.profiles[parkID] == nil {
+		s.profiles[parkID] = make(map[DriverID]DriverProfile)
+	}
+	s.profiles[parkID][profile.Profile.Id] = profile
+	return nil
+}
+
+// ParseTime Разбирает время в формате API. API возвращает ISO 8601 как со смещением "+03:00",
+// так и "+0300"
+func ParseTime(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999Z0700"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", value)
+}
@@ -0,0 +1,254 @@
+package yandex_taxi_go_test
+
+import (
+	"context"
+	"errors"
+	fleet "github.com/sinland/yandex-taxi-go"
+	"github.com/sinland/yandex-taxi-go/fleettest"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newSyncServer(t *testing.T) (*fleettest.Server, *fakeClock, *fleet.Client) {
+	clock := &fakeClock{now: time.Date(2024, time.March, 3, 10, 0, 0, 0, time.UTC)}
+
+	s := fleettest.NewServer()
+	t.Cleanup(s.Close)
+	s.SetClock(clock.Now)
+	s.AddPark(fleettest.Park{ID: "park-1", Name: "Park", City: "Москва"})
+
+	return s, clock, fleet.NewClient(fleet.ClientConfig{ClientID: "client", APIKey: "key"}, fleet.WithAPIHost(s.URL()))
+}
+
+func testDriver(id fleet.DriverID, phone string) fleet.DriverProfile {
+	return fleet.DriverProfile{
+		Profile: &fleet.DriverProfileData{Id: id, LastName: "Иванов", Phones: []string{phone}},
+	}
+}
+
+func collectEvents(events *[]fleet.DriverEvent) func(fleet.DriverEvent) error {
+	return func(e fleet.DriverEvent) error {
+		*events = append(*events, e)
+		return nil
+	}
+}
+
+func newDriverSync(t *testing.T, c *fleet.Client, cfg fleet.DriverSyncConfig) *fleet.DriverSync {
+	sync, err := fleet.NewDriverSync(c, cfg)
+	require.NoError(t, err)
+	return sync
+}
+
+func TestDriverSync(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, clock, c := newSyncServer(t)
+
+	checkpoints := fleet.NewMemoryCheckpointStore()
+	state := fleet.NewMemoryDriverState()
+	cfg := fleet.DriverSyncConfig{ParkID: "park-1", Checkpoints: checkpoints, State: state, PageLimit: 1}
+	sync := newDriverSync(t, c, cfg)
+
+	require.NoError(t, s.PutDriver("park-1", testDriver("driver-1", "+79990000001")))
+	clock.Advance(time.Second)
+	require.NoError(t, s.PutDriver("park-1", testDriver("driver-2", "+79990000002")))
+
+	var events []fleet.DriverEvent
+	n, err := sync.Sync(ctx, collectEvents(&events))
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, fleet.DriverCreated, events[0].Type)
+	require.Equal(t, fleet.DriverID("driver-1"), events[0].DriverID)
+	require.Equal(t, fleet.DriverID("driver-2"), events[1].DriverID)
+	require.Nil(t, events[1].Previous)
+
+	cp, found, err := checkpoints.Load(ctx, "drivers/park-1")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.True(t, clock.Now().Equal(cp.Watermark))
+
+	t.Run("no changes", func(t *testing.T) {
+		events = nil
+		n, err = sync.Sync(ctx, collectEvents(&events))
+		require.NoError(t, err)
+		require.Zero(t, n)
+	})
+
+	t.Run("updated and created", func(t *testing.T) {
+		clock.Advance(time.Minute)
+		require.NoError(t, s.PutDriver("park-1", testDriver("driver-1", "+79990000009")))
+		clock.Advance(time.Second)
+		require.NoError(t, s.PutDriver("park-1", testDriver("driver-3", "+79990000003")))
+
+		events = nil
+		n, err = sync.Sync(ctx, collectEvents(&events))
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+
+		require.Equal(t, fleet.DriverUpdated, events[0].Type)
+		require.Equal(t, fleet.DriverID("driver-1"), events[0].DriverID)
+		require.Equal(t, []string{"+79990000001"}, events[0].Previous.Profile.Phones)
+		require.Equal(t, []fleet.FieldChange{{
+			Field: "driver_profile.phones",
+			Old:   []any{"+79990000001"},
+			New:   []any{"+79990000009"},
+		}}, events[0].Changes)
+
+		require.Equal(t, fleet.DriverCreated, events[1].Type)
+		require.Equal(t, fleet.DriverID("driver-3"), events[1].DriverID)
+	})
+
+	t.Run("handler error keeps checkpoint", func(t *testing.T) {
+		clock.Advance(time.Minute)
+		require.NoError(t, s.PutDriver("park-1", testDriver("driver-2", "+79990000008")))
+
+		before, _, _ := checkpoints.Load(ctx, "drivers/park-1")
+
+		errHandler := errors.New("handler failed")
+		_, err = sync.Sync(ctx, func(fleet.DriverEvent) error { return errHandler })
+		require.ErrorIs(t, err, errHandler)
+
+		after, _, _ := checkpoints.Load(ctx, "drivers/park-1")
+		require.Equal(t, before, after)
+
+		events = nil
+		n, err = newDriverSync(t, c, cfg).Sync(ctx, collectEvents(&events))
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		require.Equal(t, fleet.DriverID("driver-2"), events[0].DriverID)
+	})
+
+	t.Run("events per page", func(t *testing.T) {
+		clock.Advance(time.Minute)
+		require.NoError(t, s.PutDriver("park-1", testDriver("driver-1", "+79990000007")))
+		clock.Advance(time.Second)
+		require.NoError(t, s.PutDriver("park-1", testDriver("driver-3", "+79990000006")))
+
+		requests := s.Requests(fleet.EndpointDriverProfilesList)
+		var pages []int
+		n, err = sync.Sync(ctx, func(fleet.DriverEvent) error {
+			pages = append(pages, s.Requests(fleet.EndpointDriverProfilesList)-requests)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.Less(t, pages[0], pages[1], "event must be delivered before the next page is requested")
+	})
+}
+
+func TestDriverSync_PersistentCheckpoints(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, _, c := newSyncServer(t)
+	require.NoError(t, s.PutDriver("park-1", testDriver("driver-1", "+79990000001")))
+
+	checkpoints := fleet.NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoints.json"))
+
+	_, err := fleet.NewDriverSync(c, fleet.DriverSyncConfig{ParkID: "park-1", Checkpoints: checkpoints})
+	require.Error(t, err)
+
+	state := fleet.NewMemoryDriverState()
+	var events []fleet.DriverEvent
+	n, err := newDriverSync(t, c, fleet.DriverSyncConfig{ParkID: "park-1", Checkpoints: checkpoints, State: state}).
+		Sync(ctx, collectEvents(&events))
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+}
+
+func TestDriverSync_UpdateBetweenPages(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, clock, _ := newSyncServer(t)
+	for _, id := range []fleet.DriverID{"driver-1", "driver-2", "driver-3", "driver-4"} {
+		require.NoError(t, s.PutDriver("park-1", testDriver(id, "+79990000001")))
+		clock.Advance(time.Second)
+	}
+
+	// После первой страницы обновляется уже прочитанный профиль, и он уходит в конец порядка по updated_at
+	var requests atomic.Int32
+	c := fleet.NewClient(fleet.ClientConfig{ClientID: "client", APIKey: "key"}, fleet.WithAPIHost(s.URL()),
+		fleet.WithMiddleware(func(next fleet.RoundTrip) fleet.RoundTrip {
+			return func(req *http.Request) (*http.Response, error) {
+				res, err := next(req)
+				if requests.Add(1) == 1 {
+					_ = s.PutDriver("park-1", testDriver("driver-2", "+79990000002"))
+				}
+				return res, err
+			}
+		}))
+
+	var events []fleet.DriverEvent
+	n, err := newDriverSync(t, c, fleet.DriverSyncConfig{ParkID: "park-1", PageLimit: 2}).Sync(ctx, collectEvents(&events))
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+
+	var ids []fleet.DriverID
+	for _, e := range events {
+		ids = append(ids, e.DriverID)
+	}
+	require.Equal(t, []fleet.DriverID{"driver-1", "driver-2", "driver-3", "driver-4", "driver-2"}, ids)
+	require.Equal(t, fleet.DriverUpdated, events[4].Type)
+}
+
+func TestDriverSync_Run(t *testing.T) {
+	t.Parallel()
+
+	s, _, c := newSyncServer(t)
+	require.NoError(t, s.PutDriver("park-1", testDriver("driver-1", "+79990000001")))
+
+	sync := newDriverSync(t, c, fleet.DriverSyncConfig{ParkID: "park-1", Interval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan fleet.DriverEvent, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- sync.Run(ctx, func(e fleet.DriverEvent) error {
+			events <- e
+			return nil
+		})
+	}()
+
+	require.Equal(t, fleet.DriverID("driver-1"), (<-events).DriverID)
+	require.Eventually(t, func() bool { return s.Requests(fleet.EndpointDriverProfilesList) >= 3 }, time.Second, time.Millisecond)
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestDriverSync_RunNegativeInterval(t *testing.T) {
+	t.Parallel()
+
+	s, _, c := newSyncServer(t)
+	require.NoError(t, s.PutDriver("park-1", testDriver("driver-1", "+79990000001")))
+
+	sync := newDriverSync(t, c, fleet.DriverSyncConfig{ParkID: "park-1", Interval: -time.Second})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, sync.Run(ctx, func(fleet.DriverEvent) error { return nil }), context.Canceled)
+}
@@ -0,0 +1,206 @@
+package yandex_taxi_go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+// compactMinGarbage Наименьшее число устаревших строк журнала, при котором выполняется автоматическое сжатие
+const compactMinGarbage = 1024
+
+// ErrStoreClosed Хранилище закрыто
+var ErrStoreClosed = errors.New("store is closed")
+
+const (
+	storeOpUpsert = "upsert"
+	storeOpDelete = "delete"
+)
+
+// storeLogEntry Строка журнала FileStore
+type storeLogEntry struct {
+	Op   string          `json:"op"`
+	Kind StoreKind       `json:"kind"`
+	ID   string          `json:"id"`
+	Doc  json.RawMessage `json:"doc,omitempty"`
+}
+
+// FileStore Хранилище записей в файле формата JSON Lines. Каждое изменение дописывается в конец
+// файла отдельной строкой, при открытии журнал воспроизводится в память, чтение выполняется из памяти.
+// Когда устаревших строк становится больше, чем живых записей (и не меньше 1024), журнал
+// автоматически сжимается: файл атомарно перезаписывается одними актуальными записями.
+// Оборванная последняя строка, оставшаяся после сбоя во время записи, при открытии отбрасывается.
+// Запись не вызывает fsync; для гарантии сохранности после отключения питания вызывайте Sync
+type FileStore struct {
+	mem *MemoryStore
+	log *jsonlFile
+}
+
+// OpenFileStore Открывает хранилище в файле path, создавая файл при необходимости
+func OpenFileStore(path string) (*FileStore, error) {
+	s := &FileStore{mem: NewMemoryStore()}
+
+	log, err := openJSONL(path, func(line []byte) error {
+		var entry storeLogEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return err
+		}
+		s.apply(entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.log = log
+	return s, nil
+}
+
+func (s *FileStore) apply(entry storeLogEntry) {
+	switch entry.Op {
+	case storeOpUpsert:
+		s.mem.put(entry.Kind, entry.ID, entry.Doc)
+	case storeOpDelete:
+		delete(s.mem.records[entry.Kind], entry.ID)
+	}
+}
+
+// Upsert Добавляет или заменяет запись. Запись, совпадающая с сохраненной, в журнал не пишется
+func (s *FileStore) Upsert(_ context.Context, kind StoreKind, id string, doc json.RawMessage) error {
+	// json.Compact гарантирует, что документ займет одну строку
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, doc); err != nil {
+		return err
+	}
+
+	s.mem.mu.Lock()
+	defer s.mem.mu.Unlock()
+
+	if current, ok := s.mem.records[kind][id]; ok && bytes.Equal(current, compact.Bytes()) {
+		return nil
+	}
+	return s.write(storeLogEntry{Op: storeOpUpsert, Kind: kind, ID: id, Doc: compact.Bytes()})
+}
+
+// Delete Удаляет запись
+func (s *FileStore) Delete(_ context.Context, kind StoreKind, id string) error {
+	s.mem.mu.Lock()
+	defer s.mem.mu.Unlock()
+
+	if _, ok := s.mem.records[kind][id]; !ok {
+		return nil
+	}
+	return s.write(storeLogEntry{Op: storeOpDelete, Kind: kind, ID: id})
+}
+
+// write Дописывает строку в журнал и применяет изменение в памяти. Вызывается под s.mem.mu.
+// Изменение уже сохранено, поэтому ошибка автоматического сжатия только записывается в журнал,
+// сжатие повторяется при следующей записи
+func (s *FileStore) write(entry storeLogEntry) error {
+	if err := s.log.append(entry); err != nil {
+		return err
+	}
+	s.apply(entry)
+
+	if garbage := s.log.lines - s.live(); garbage >= compactMinGarbage && garbage > s.live() {
+		if err := s.compact(); err != nil {
+			slog.Default().Warn("fleet store compaction failed", "path", s.log.path, "error", err)
+		}
+	}
+	return nil
+}
+
+func (s *FileStore) live() int {
+	n := 0
+	for _, records := range s.mem.records {
+		n += len(records)
+	}
+	return n
+}
+
+// Get Возвращает запись по идентификатору
+func (s *FileStore) Get(ctx context.Context, kind StoreKind, id string) (json.RawMessage, bool, error) {
+	return s.mem.Get(ctx, kind, id)
+}
+
+// Find Передает в fn записи, у которых поле field равно value
+func (s *FileStore) Find(ctx context.Context, kind StoreKind, field string, value any, fn func(id string, doc json.RawMessage) error) error {
+	return s.mem.Find(ctx, kind, field, value, fn)
+}
+
+// Iterate Передает в fn все записи вида kind в порядке идентификаторов
+func (s *FileStore) Iterate(ctx context.Context, kind StoreKind, fn func(id string, doc json.RawMessage) error) error {
+	return s.mem.Iterate(ctx, kind, fn)
+}
+
+// Len Число записей вида kind
+func (s *FileStore) Len(kind StoreKind) int {
+	return s.mem.Len(kind)
+}
+
+// Lines Число строк в журнале, включая устаревшие
+func (s *FileStore) Lines() int {
+	s.mem.mu.RLock()
+	defer s.mem.mu.RUnlock()
+	return s.log.lines
+}
+
+// Compact Перезаписывает журнал, оставляя по одной строке на каждую живую запись
+func (s *FileStore) Compact() error {
+	s.mem.mu.Lock()
+	defer s.mem.mu.Unlock()
+
+	return s.compact()
+}
+
+func (s *FileStore) compact() error {
+	kinds := make([]StoreKind, 0, len(s.mem.records))
+	for kind := range s.mem.records {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+
+	var buf bytes.Buffer
+	lines := 0
+	for _, kind := range kinds {
+		ids := make([]string, 0, len(s.mem.records[kind]))
+		for id := range s.mem.records[kind] {
+			ids = append(ids, id)
+		}
+		slices.Sort(ids)
+
+		for _, id := range ids {
+			line, err := json.Marshal(storeLogEntry{Op: storeOpUpsert, Kind: kind, ID: id, Doc: s.mem.records[kind][id]})
+			if err != nil {
+				return err
+			}
+			buf.Write(line)
+			buf.WriteByte('\n')
+			lines++
+		}
+	}
+
+	if err := s.log.replace(buf.Bytes(), lines); err != nil {
+		return fmt.Errorf("compact %s: %w", s.log.path, err)
+	}
+	return nil
+}
+
+// Sync Сбрасывает журнал на диск
+func (s *FileStore) Sync() error {
+	s.mem.mu.Lock()
+	defer s.mem.mu.Unlock()
+
+	return s.log.sync()
+}
+
+// Close Закрывает файл журнала. Чтение из памяти после закрытия остается доступным, изменения возвращают ErrStoreClosed
+func (s *FileStore) Close() error {
+	s.mem.mu.Lock()
+	defer s.mem.mu.Unlock()
+
+	return s.log.close()
+}
@@ -0,0 +1,141 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func openTestFileStore(t *testing.T, path string) *FileStore {
+	s, err := OpenFileStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+
+	testStoreContract(t, openTestFileStore(t, filepath.Join(t.TempDir(), "store.jsonl")))
+}
+
+func TestFileStore_Reopen(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "store.jsonl")
+
+	s := openTestFileStore(t, path)
+	orders := NewCollection[Order](s)
+	require.NoError(t, orders.Upsert(ctx, Order{Id: "order-1", Status: "driving"}))
+	require.NoError(t, orders.Upsert(ctx, Order{Id: "order-2", Status: "complete"}))
+	require.NoError(t, orders.Upsert(ctx, Order{Id: "order-1", Status: "complete"}))
+	require.NoError(t, orders.Upsert(ctx, Order{Id: "order-1", Status: "complete"}))
+	require.NoError(t, orders.Delete(ctx, "order-2"))
+	require.Equal(t, 4, s.Lines(), "unchanged upsert must not be written")
+	require.NoError(t, s.Sync())
+	require.NoError(t, s.Close())
+	require.ErrorIs(t, orders.Upsert(ctx, Order{Id: "order-3"}), ErrStoreClosed)
+
+	reopened := openTestFileStore(t, path)
+	got, found, err := NewCollection[Order](reopened).Get(ctx, "order-1")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "complete", got.Status)
+	require.Equal(t, 1, reopened.Len(KindOrder))
+
+	t.Run("compact", func(t *testing.T) {
+		require.NoError(t, reopened.Compact())
+		require.Equal(t, 1, reopened.Lines())
+
+		require.NoError(t, NewCollection[Order](reopened).Upsert(ctx, Order{Id: "order-4"}))
+		require.NoError(t, reopened.Close())
+
+		compacted := openTestFileStore(t, path)
+		require.Equal(t, 2, compacted.Lines())
+		require.Equal(t, 2, compacted.Len(KindOrder))
+	})
+}
+
+func TestFileStore_TornWrite(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "store.jsonl")
+
+	s := openTestFileStore(t, path)
+	require.NoError(t, NewCollection[Vehicle](s).Upsert(ctx, Vehicle{Id: "car-1"}))
+	require.NoError(t, s.Close())
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"op":"upsert","kind":"vehicles","id":"car-2","doc":{"id":"ca`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	reopened := openTestFileStore(t, path)
+	require.Equal(t, 1, reopened.Len(KindVehicle))
+	require.NoError(t, NewCollection[Vehicle](reopened).Upsert(ctx, Vehicle{Id: "car-3"}))
+	require.NoError(t, reopened.Close())
+
+	again := openTestFileStore(t, path)
+	require.Equal(t, 2, again.Len(KindVehicle))
+
+	t.Run("corrupted line", func(t *testing.T) {
+		bad := filepath.Join(t.TempDir(), "bad.jsonl")
+		require.NoError(t, os.WriteFile(bad, []byte("not json\n{}\n"), 0o600))
+
+		_, err := OpenFileStore(bad)
+		require.ErrorContains(t, err, "bad.jsonl:1")
+	})
+}
+
+func TestFileStore_AutoCompact(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "store.jsonl")
+
+	s := openTestFileStore(t, path)
+	cars := NewCollection[Vehicle](s)
+	for i := 0; i < 3*compactMinGarbage; i++ {
+		require.NoError(t, cars.Upsert(ctx, Vehicle{Id: CarID(fmt.Sprintf("car-%d", i%10)), Year: i}))
+	}
+	require.Less(t, s.Lines(), compactMinGarbage+20)
+	require.NoError(t, s.Close())
+
+	reopened := openTestFileStore(t, path)
+	require.Equal(t, 10, reopened.Len(KindVehicle))
+
+	last := 3*compactMinGarbage - 1
+	got, found, err := NewCollection[Vehicle](reopened).Get(ctx, fmt.Sprintf("car-%d", last%10))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, last, got.Year)
+
+	t.Run("compaction failure", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "store.jsonl")
+		s := openTestFileStore(t, path)
+		cars := NewCollection[Vehicle](s)
+
+		// Каталог на месте журнала не дает заменить файл при сжатии, запись идет в открытый дескриптор
+		require.NoError(t, os.Remove(path))
+		require.NoError(t, os.MkdirAll(filepath.Join(path, "busy"), 0o700))
+		for i := 0; i < 2*compactMinGarbage; i++ {
+			require.NoError(t, cars.Upsert(ctx, Vehicle{Id: CarID(fmt.Sprintf("car-%d", i%10)), Year: i}))
+		}
+		require.Equal(t, 2*compactMinGarbage, s.Lines())
+
+		require.NoError(t, os.RemoveAll(path))
+		require.NoError(t, cars.Upsert(ctx, Vehicle{Id: "car-0", Year: -1}))
+		require.Equal(t, 10, s.Lines(), "compaction must be retried on the next write")
+
+		got, found, err := cars.Get(ctx, "car-0")
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, -1, got.Year)
+	})
+}
@@ -0,0 +1,419 @@
+// Package fixtures Генераторы правдоподобных тестовых данных Fleet API на основе gofakeit.
+// Генерация детерминирована: фабрики с одинаковым seed выдают одинаковую последовательность данных
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	fleet "github.com/sinland/yandex-taxi-go"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Буквы, допустимые в российских государственных номерах (совпадают по написанию с латиницей)
+var plateLetters = []string{"А", "В", "Е", "К", "М", "Н", "О", "Р", "С", "Т", "У", "Х"}
+
+var (
+	carModels = map[string][]string{
+		"Kia":           {"Rio", "K5", "Optima", "Ceed"},
+		"Hyundai":       {"Solaris", "Sonata", "Elantra"},
+		"Skoda":         {"Octavia", "Rapid", "Superb"},
+		"Volkswagen":    {"Polo", "Jetta", "Passat"},
+		"Toyota":        {"Camry", "Corolla"},
+		"Renault":       {"Logan", "Arkana"},
+		"Mercedes-Benz": {"E-klasse", "S-klasse"},
+	}
+	carBrands     = []string{"Kia", "Hyundai", "Skoda", "Volkswagen", "Toyota", "Renault", "Mercedes-Benz"}
+	carColors     = []string{"Белый", "Черный", "Серый", "Серебристый", "Синий", "Желтый"}
+	carCategories = []string{"econom", "comfort", "comfort_plus", "business", "minivan", "express"}
+	carAmenities  = []string{"conditioner", "wifi", "child_seat", "animals", "smoking", "charge"}
+	carStatuses   = []string{"working", "not_working", "repairing", "no_driver", "pending"}
+
+	firstNames  = []string{"Иван", "Алексей", "Сергей", "Дмитрий", "Андрей", "Михаил", "Николай", "Руслан", "Тимур", "Азамат"}
+	lastNames   = []string{"Иванов", "Смирнов", "Кузнецов", "Попов", "Васильев", "Петров", "Соколов", "Михайлов", "Новиков", "Федоров"}
+	middleNames = []string{"Иванович", "Сергеевич", "Александрович", "Андреевич", "Петрович", "Николаевич", "Русланович"}
+
+	workStatuses    = []string{"working", "not_working", "fired"}
+	currentStatuses = []string{"online", "busy", "offline"}
+	employmentTypes = []string{"selfemployed", "individual_entrepreneur", "park_employee"}
+
+	orderStatuses   = []string{"complete", "complete", "complete", "cancelled", "failed"}
+	orderCategories = []string{"econom", "comfort", "comfort_plus", "business"}
+	orderProviders  = []string{"platform", "partner"}
+	paymentMethods  = []string{"cash", "cashless", "corp"}
+	txCategories    = map[string]string{
+		"cash_collected":         "Наличные",
+		"card":                   "Оплата картой",
+		"platform_ride_fee":      "Комиссия сервиса за заказ",
+		"partner_ride_fee":       "Комиссия партнёра за заказ",
+		"partner_service_manual": "Ручные списания",
+		"bonus":                  "Бонус",
+	}
+	txCategoryIDs = []string{"cash_collected", "card", "platform_ride_fee", "partner_ride_fee", "partner_service_manual", "bonus"}
+	streets       = []string{"Тверская улица", "Ленинский проспект", "улица Арбат", "Профсоюзная улица", "Кутузовский проспект"}
+)
+
+// Factory Генератор тестовых данных. Безопасен для конкурентного использования
+type Factory struct {
+	mu    sync.Mutex
+	faker *gofakeit.Faker
+	now   time.Time
+}
+
+// New Создает фабрику с заданным seed
+func New(seed uint64) *Factory {
+	return &Factory{
+		faker: gofakeit.New(seed),
+		now:   time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+// ID Идентификатор в формате API: 32 шестнадцатеричных символа
+func (f *Factory) ID() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.id()
+}
+
+func (f *Factory) id() string {
+	return strings.ReplaceAll(f.faker.UUID(), "-", "")
+}
+
+// Plate Российский государственный номер, например А123ВС77
+func (f *Factory) Plate() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.plate()
+}
+
+func (f *Factory) plate() string {
+	region := f.faker.RandomString([]string{"77", "97", "99", "177", "197", "199", "777", "50", "750", "78", "178"})
+	return f.faker.RandomString(plateLetters) +
+		f.faker.Numerify("###") +
+		f.faker.RandomString(plateLetters) +
+		f.faker.RandomString(plateLetters) +
+		region
+}
+
+// VIN Идентификационный номер ТС с корректной контрольной цифрой
+func (f *Factory) VIN() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.vin()
+}
+
+func (f *Factory) vin() string {
+	const alphabet = "ABCDEFGHJKLMNPRSTUVWXYZ0123456789"
+
+	b := make([]byte, 17)
+	for i := range b {
+		b[i] = alphabet[f.faker.IntRange(0, len(alphabet)-1)]
+	}
+	b[8] = VINCheckDigit(string(b))
+	return string(b)
+}
+
+// VINCheckDigit Вычисляет контрольную цифру (девятый символ) VIN по ISO 3779
+func VINCheckDigit(vin string) byte {
+	weights := []int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+	values := map[byte]int{
+		'A': 1, 'B': 2, 'C': 3, 'D': 4, 'E': 5, 'F': 6, 'G': 7, 'H': 8,
+		'J': 1, 'K': 2, 'L': 3, 'M': 4, 'N': 5, 'P': 7, 'R': 9,
+		'S': 2, 'T': 3, 'U': 4, 'V': 5, 'W': 6, 'X': 7, 'Y': 8, 'Z': 9,
+	}
+
+	sum := 0
+	for i := 0; i < len(vin) && i < len(weights); i++ {
+		c := vin[i]
+		v, ok := values[c]
+		if !ok && c >= '0' && c <= '9' {
+			v = int(c - '0')
+		}
+		sum += v * weights[i]
+	}
+
+	if check := sum % 11; check != 10 {
+		return byte('0' + check)
+	}
+	return 'X'
+}
+
+// Phone Российский номер мобильного телефона в формате +79XXXXXXXXX
+func (f *Factory) Phone() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.phone()
+}
+
+func (f *Factory) phone() string {
+	return f.faker.Numerify("+79#########")
+}
+
+// Vehicle Создает ТС. Функции overrides применяются к сгенерированному значению по порядку
+func (f *Factory) Vehicle(overrides ...func(*fleet.Vehicle)) fleet.Vehicle {
+	f.mu.Lock()
+	brand := f.faker.RandomString(carBrands)
+	v := fleet.Vehicle{
+		Id:               fleet.CarID(f.id()),
+		Amenities:        f.subset(carAmenities),
+		Brand:            brand,
+		Callsign:         f.faker.Numerify("######"),
+		Category:         f.subset(carCategories),
+		Color:            f.faker.RandomString(carColors),
+		Model:            f.faker.RandomString(carModels[brand]),
+		Number:           f.plate(),
+		RegistrationCert: f.faker.Numerify("99 ## ######"),
+		Status:           f.faker.RandomString(carStatuses),
+		Vin:              f.vin(),
+		Year:             f.faker.IntRange(2012, 2024),
+	}
+	f.mu.Unlock()
+
+	for _, o := range overrides {
+		o(&v)
+	}
+	return v
+}
+
+// DriverProfile Создает профиль водителя с ТС, счетом и водительским удостоверением.
+// Функции overrides применяются к сгенерированному значению по порядку
+func (f *Factory) DriverProfile(overrides ...func(*fleet.DriverProfile)) fleet.DriverProfile {
+	car := f.Vehicle()
+
+	f.mu.Lock()
+	created := f.now.Add(-time.Duration(f.faker.IntRange(24, 24*365*3)) * time.Hour)
+	birth := f.now.AddDate(-f.faker.IntRange(21, 65), 0, -f.faker.IntRange(0, 364))
+	issued := f.now.AddDate(-f.faker.IntRange(1, 9), 0, -f.faker.IntRange(0, 364))
+	license := f.faker.Numerify("##########")
+
+	p := fleet.DriverProfile{
+		Accounts: []fleet.DriverProfileAccount{{
+			Id:           f.id(),
+			Balance:      fmt.Sprintf("%.2f", f.faker.Price(-1000, 50000)),
+			BalanceLimit: fmt.Sprintf("%.2f", f.faker.Price(0, 1000)),
+			Currency:     "RUB",
+			Type:         "current",
+		}},
+		Car: &car,
+		CurrentStatus: &fleet.DriverProfileCurrentStatus{
+			Status:          f.faker.RandomString(currentStatuses),
+			StatusUpdatedAt: f.now.Add(-time.Duration(f.faker.IntRange(1, 3600)) * time.Second).Format(time.RFC3339),
+		},
+		Profile: &fleet.DriverProfileData{
+			Id:          fleet.DriverID(f.id()),
+			CreatedDate: created.Format(time.RFC3339),
+			DriverLicense: fleet.DriverLicense{
+				IssueDate:        issued.Format(time.DateOnly),
+				ExpirationDate:   issued.AddDate(10, 0, 0).Format(time.DateOnly),
+				Number:           license[:2] + " " + license[2:4] + " " + license[4:],
+				NormalizedNumber: license,
+				Country:          "rus",
+				BirthDate:        birth.Format(time.DateOnly),
+			},
+			EmploymentType: f.faker.RandomString(employmentTypes),
+			FirstName:      f.faker.RandomString(firstNames),
+			LastName:       f.faker.RandomString(lastNames),
+			MiddleName:     f.faker.RandomString(middleNames),
+			ParkId:         fleet.ParkID(f.id()),
+			Phones:         []string{f.phone()},
+			WorkRuleId:     f.id(),
+			WorkStatus:     f.faker.RandomString(workStatuses),
+		},
+	}
+	f.mu.Unlock()
+
+	for _, o := range overrides {
+		o(&p)
+	}
+	return p
+}
+
+// InPark Переопределение парка профиля водителя
+func InPark(parkID fleet.ParkID) func(*fleet.DriverProfile) {
+	return func(p *fleet.DriverProfile) {
+		if p.Profile != nil {
+			p.Profile.ParkId = parkID
+		}
+	}
+}
+
+// Order Создает заказ, забронированный в течение суток до текущего времени фабрики.
+// Функции overrides применяются к сгенерированному значению по порядку
+func (f *Factory) Order(overrides ...func(*fleet.Order)) fleet.Order {
+	car := f.Vehicle()
+
+	f.mu.Lock()
+	booked := f.now.Add(-time.Duration(f.faker.IntRange(60, 24*3600)) * time.Second)
+	status := f.faker.RandomString(orderStatuses)
+
+	o := fleet.Order{
+		Id:            fleet.OrderID(f.id()),
+		ShortId:       f.faker.IntRange(1000, 999999),
+		Status:        status,
+		CreatedAt:     booked.Add(-time.Duration(f.faker.IntRange(0, 600)) * time.Second).Format(time.RFC3339),
+		BookedAt:      booked.Format(time.RFC3339),
+		Provider:      f.faker.RandomString(orderProviders),
+		Category:      f.faker.RandomString(orderCategories),
+		PaymentMethod: f.faker.RandomString(paymentMethods),
+		Price:         fmt.Sprintf("%.2f", f.faker.Price(150, 5000)),
+		DriverProfile: &fleet.OrderDriverProfile{
+			Id:   fleet.DriverID(f.id()),
+			Name: f.faker.RandomString(lastNames) + " " + f.faker.RandomString(firstNames),
+		},
+		Car: &fleet.OrderCar{
+			Id:         car.Id,
+			BrandModel: car.Brand + " " + car.Model,
+			Callsign:   car.Callsign,
+			License:    fleet.OrderCarLicense{Number: car.Number},
+		},
+		AddressFrom: f.address(),
+		RoutePoints: []fleet.OrderAddress{*f.address()},
+	}
+	if status == "complete" {
+		ended := booked.Add(time.Duration(f.faker.IntRange(5, 90)) * time.Minute)
+		o.EndedAt = ended.Format(time.RFC3339)
+		o.Mileage = fmt.Sprintf("%d", f.faker.IntRange(500, 60000))
+	}
+	f.mu.Unlock()
+
+	for _, override := range overrides {
+		override(&o)
+	}
+	return o
+}
+
+// address Адрес в Москве
+func (f *Factory) address() *fleet.OrderAddress {
+	return &fleet.OrderAddress{
+		Address: fmt.Sprintf("Москва, %s, %d", f.faker.RandomString(streets), f.faker.IntRange(1, 120)),
+		Lat:     55.55 + f.faker.Float64Range(0, 0.35),
+		Lon:     37.35 + f.faker.Float64Range(0, 0.5),
+	}
+}
+
+// Transaction Создает транзакцию водителя, проведенную в течение суток до текущего времени фабрики.
+// Функции overrides применяются к сгенерированному значению по порядку
+func (f *Factory) Transaction(overrides ...func(*fleet.Transaction)) fleet.Transaction {
+	f.mu.Lock()
+	category := f.faker.RandomString(txCategoryIDs)
+	amount := f.faker.Price(10, 3000)
+	if category == "platform_ride_fee" || category == "partner_ride_fee" || category == "partner_service_manual" {
+		amount = -amount
+	}
+
+	tx := fleet.Transaction{
+		Id:              fleet.TransactionID(f.id()),
+		EventAt:         f.now.Add(-time.Duration(f.faker.IntRange(1, 24*3600)) * time.Second).Format(time.RFC3339),
+		CategoryId:      category,
+		CategoryName:    txCategories[category],
+		GroupId:         category,
+		Amount:          fmt.Sprintf("%.4f", amount),
+		CurrencyCode:    "RUB",
+		Description:     txCategories[category],
+		CreatedBy:       fleet.TransactionCreatedBy{Identity: f.faker.RandomString([]string{"platform", "fleet-api", "dispatcher"})},
+		DriverProfileId: fleet.DriverID(f.id()),
+	}
+	if category != "partner_service_manual" && category != "bonus" {
+		tx.OrderId = fleet.OrderID(f.id())
+	}
+	f.mu.Unlock()
+
+	for _, o := range overrides {
+		o(&tx)
+	}
+	return tx
+}
+
+// Vehicles Создает n ТС
+func (f *Factory) Vehicles(n int, overrides ...func(*fleet.Vehicle)) []fleet.Vehicle {
+	out := make([]fleet.Vehicle, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, f.Vehicle(overrides...))
+	}
+	return out
+}
+
+// DriverProfiles Создает n профилей водителей
+func (f *Factory) DriverProfiles(n int, overrides ...func(*fleet.DriverProfile)) []fleet.DriverProfile {
+	out := make([]fleet.DriverProfile, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, f.DriverProfile(overrides...))
+	}
+	return out
+}
+
+// Orders Создает n заказов
+func (f *Factory) Orders(n int, overrides ...func(*fleet.Order)) []fleet.Order {
+	out := make([]fleet.Order, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, f.Order(overrides...))
+	}
+	return out
+}
+
+// Transactions Создает n транзакций
+func (f *Factory) Transactions(n int, overrides ...func(*fleet.Transaction)) []fleet.Transaction {
+	out := make([]fleet.Transaction, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, f.Transaction(overrides...))
+	}
+	return out
+}
+
+// subset Случайное непустое подмножество значений
+func (f *Factory) subset(values []string) []string {
+	n := f.faker.IntRange(1, len(values))
+	shuffled := append([]string(nil), values...)
+	f.faker.ShuffleStrings(shuffled)
+	return shuffled[:n]
+}
+
+// VehicleJSON ТС в формате API
+func VehicleJSON(v fleet.Vehicle) (json.RawMessage, error) {
+	return json.Marshal(v)
+}
+
+// DriverProfileJSON Профиль водителя в формате API
+func DriverProfileJSON(p fleet.DriverProfile) (json.RawMessage, error) {
+	return json.Marshal(p)
+}
+
+// CarsListResponseJSON Ответ /v1/parks/cars/list в формате API
+func CarsListResponseJSON(total, offset, limit int, cars []fleet.Vehicle) (json.RawMessage, error) {
+	return json.Marshal(fleet.GetCarsListResult{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+		Cars:   append([]fleet.Vehicle{}, cars...),
+	})
+}
+
+// DriverProfilesResponseJSON Ответ /v1/parks/driver-profiles/list в формате API
+func DriverProfilesResponseJSON(total, offset, limit int, parks []fleet.DriverProfilePark, profiles []fleet.DriverProfile) (json.RawMessage, error) {
+	return json.Marshal(fleet.GetDriverProfilesResult{
+		Total:          total,
+		Offset:         offset,
+		Limit:          limit,
+		Parks:          append([]fleet.DriverProfilePark{}, parks...),
+		DriverProfiles: append([]fleet.DriverProfile{}, profiles...),
+	})
+}
+
+// OrdersListResponseJSON Ответ /v1/parks/orders/list в формате API
+func OrdersListResponseJSON(cursor string, limit int, orders []fleet.Order) (json.RawMessage, error) {
+	return json.Marshal(fleet.GetOrdersListResult{
+		Cursor: cursor,
+		Limit:  limit,
+		Orders: append([]fleet.Order{}, orders...),
+	})
+}
+
+// TransactionsListResponseJSON Ответ /v2/parks/transactions/list и /v2/parks/driver-profiles/transactions/list в формате API
+func TransactionsListResponseJSON(cursor string, transactions []fleet.Transaction) (json.RawMessage, error) {
+	return json.Marshal(fleet.GetTransactionsListResult{
+		Cursor:       cursor,
+		Transactions: append([]fleet.Transaction{}, transactions...),
+	})
+}
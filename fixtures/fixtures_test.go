@@ -0,0 +1,162 @@
+package fixtures
+
+import (
+	"context"
+	fleet "github.com/sinland/yandex-taxi-go"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestFactory_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	a, b := New(42), New(42)
+
+	require.Equal(t, a.Vehicles(3), b.Vehicles(3))
+	require.Equal(t, a.DriverProfiles(3), b.DriverProfiles(3))
+	require.NotEqual(t, New(1).Vehicle(), New(2).Vehicle())
+}
+
+func TestFactory_Vehicle(t *testing.T) {
+	t.Parallel()
+
+	f := New(7)
+	plate := regexp.MustCompile(`^[АВЕКМНОРСТУХ]\d{3}[АВЕКМНОРСТУХ]{2}\d{2,3}$`)
+	vin := regexp.MustCompile(`^[A-HJ-NPR-Z0-9]{17}$`)
+
+	for _, v := range f.Vehicles(50) {
+		require.Regexp(t, plate, v.Number)
+		require.Regexp(t, vin, v.Vin)
+		require.Equal(t, VINCheckDigit(v.Vin), v.Vin[8])
+		require.Len(t, string(v.Id), 32)
+		require.NotEmpty(t, v.Model)
+	}
+
+	v := f.Vehicle(func(v *fleet.Vehicle) {
+		v.Brand = "Lada"
+		v.Status = "working"
+	})
+	require.Equal(t, "Lada", v.Brand)
+	require.Equal(t, "working", v.Status)
+}
+
+func TestVINCheckDigit(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, byte('X'), VINCheckDigit("1M8GDM9AXKP042788"))
+	require.Equal(t, byte('1'), VINCheckDigit("11111111111111111"))
+}
+
+func TestFactory_DriverProfile(t *testing.T) {
+	t.Parallel()
+
+	f := New(11)
+	p := f.DriverProfile(InPark("park-1"))
+
+	require.Equal(t, fleet.ParkID("park-1"), p.Profile.ParkId)
+	require.Regexp(t, `^\+79\d{9}$`, p.Profile.Phones[0])
+	require.Regexp(t, `^\d{10}$`, p.Profile.DriverLicense.NormalizedNumber)
+	require.NotNil(t, p.Car)
+	require.Len(t, p.Accounts, 1)
+	require.Less(t, p.Profile.DriverLicense.IssueDate, p.Profile.DriverLicense.ExpirationDate)
+}
+
+func TestFactory_Order(t *testing.T) {
+	t.Parallel()
+
+	f := New(5)
+	for _, o := range f.Orders(30) {
+		booked, err := time.Parse(time.RFC3339, o.BookedAt)
+		require.NoError(t, err)
+		require.True(t, booked.Before(f.now))
+		require.Len(t, string(o.Id), 32)
+		require.NotEmpty(t, o.Car.License.Number)
+
+		if o.Status == "complete" {
+			ended, err := time.Parse(time.RFC3339, o.EndedAt)
+			require.NoError(t, err)
+			require.True(t, ended.After(booked))
+		} else {
+			require.Empty(t, o.EndedAt)
+		}
+	}
+
+	o := f.Order(func(o *fleet.Order) { o.Status = "cancelled" })
+	require.Equal(t, "cancelled", o.Status)
+}
+
+func TestFactory_Transaction(t *testing.T) {
+	t.Parallel()
+
+	f := New(9)
+	for _, tx := range f.Transactions(30) {
+		eventAt, err := time.Parse(time.RFC3339, tx.EventAt)
+		require.NoError(t, err)
+		require.True(t, eventAt.Before(f.now))
+		require.NotEmpty(t, tx.CategoryName)
+		require.Regexp(t, `^-?\d+\.\d{4}$`, tx.Amount)
+	}
+}
+
+func TestWireJSON(t *testing.T) {
+	t.Parallel()
+
+	f := New(3)
+	parks := []fleet.DriverProfilePark{{Id: "park-1", City: "Москва", Name: "Park"}}
+	profiles := f.DriverProfiles(2, InPark("park-1"))
+	cars := f.Vehicles(2)
+	orders := f.Orders(2)
+	transactions := f.Transactions(2)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			data []byte
+			err  error
+		)
+		switch r.URL.Path {
+		case "/v1/parks/cars/list":
+			data, err = CarsListResponseJSON(2, 0, 1000, cars)
+		case "/v1/parks/orders/list":
+			data, err = OrdersListResponseJSON("next", 500, orders)
+		case "/v2/parks/transactions/list":
+			data, err = TransactionsListResponseJSON("", transactions)
+		default:
+			data, err = DriverProfilesResponseJSON(2, 0, 1000, parks, profiles)
+		}
+		require.NoError(t, err)
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	c := fleet.NewClient(fleet.ClientConfig{}, fleet.WithAPIHost(server.URL))
+
+	gotCars, err := c.GetCarsList(context.Background(), fleet.GetCarsListArgs{ParkID: "park-1"})
+	require.NoError(t, err)
+	require.Equal(t, cars, gotCars.Cars)
+
+	gotProfiles, err := c.GetDriverProfiles(context.Background(), fleet.GetDriverProfilesArgs{ParkID: "park-1"})
+	require.NoError(t, err)
+	require.Equal(t, profiles, gotProfiles.DriverProfiles)
+	require.Equal(t, parks, gotProfiles.Parks)
+
+	gotOrders, err := c.GetOrdersList(context.Background(), fleet.GetOrdersListArgs{ParkID: "park-1"})
+	require.NoError(t, err)
+	require.Equal(t, orders, gotOrders.Orders)
+	require.Equal(t, "next", gotOrders.Cursor)
+
+	gotTransactions, err := c.GetParkTransactions(context.Background(), fleet.GetTransactionsListArgs{ParkID: "park-1"})
+	require.NoError(t, err)
+	require.Equal(t, transactions, gotTransactions.Transactions)
+
+	raw, err := VehicleJSON(cars[0])
+	require.NoError(t, err)
+	require.Contains(t, string(raw), `"registration_cert":"`+cars[0].RegistrationCert+`"`)
+
+	raw, err = DriverProfileJSON(profiles[0])
+	require.NoError(t, err)
+	require.Contains(t, string(raw), `"normalized_number":"`+profiles[0].Profile.DriverLicense.NormalizedNumber+`"`)
+}
@@ -0,0 +1,91 @@
+package fleettest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Fault Сбой, который сервер вернет вместо обработки запроса
+type Fault struct {
+	Endpoint   string        // Путь метода API. Пустая строка - любой метод
+	Status     int           // HTTP-статус ответа, например 429 или 503. 0 - запрос обрабатывается штатно после задержки
+	Latency    time.Duration // Дополнительная задержка перед ответом
+	RetryAfter int           // Значение заголовка Retry-After в секундах, если больше нуля
+	Times      int           // Сколько запросов затронет сбой. 0 - без ограничения
+}
+
+// InjectFault Добавляет сбой. Сбои применяются в порядке добавления: запрос получает первый подходящий
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// SetLatency Задержка перед обработкой каждого запроса
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// ClearFaults Удаляет все сбои и задержку
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+	s.latency = 0
+}
+
+// takeFault Возвращает сбой для запроса и уменьшает его счетчик
+func (s *Server) takeFault(endpoint string) *Fault {
+	for i, f := range s.faults {
+		if f.Endpoint != "" && f.Endpoint != endpoint {
+			continue
+		}
+
+		taken := *f
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return &taken
+	}
+	return nil
+}
+
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests[r.URL.Path]++
+		latency := s.latency
+		fault := s.takeFault(r.URL.Path)
+		s.mu.Unlock()
+
+		if fault != nil {
+			latency += fault.Latency
+		}
+
+		if latency > 0 {
+			timer := time.NewTimer(latency)
+			select {
+			case <-r.Context().Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+
+		if fault != nil && fault.Status != 0 {
+			if fault.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(fault.RetryAfter))
+			}
+			writeError(w, fault.Status, strconv.Itoa(fault.Status), http.StatusText(fault.Status))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
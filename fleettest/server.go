@@ -0,0 +1,766 @@
+// Package fleettest Фейковый Fleet API с хранением данных в памяти для интеграционных тестов без сети
+package fleettest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	fleet "github.com/sinland/yandex-taxi-go"
+	"github.com/sinland/yandex-taxi-go/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	maxPageLimit      = 1000
+	maxOrderPageLimit = 500
+
+	headerXAPIKey   = "X-API-Key"
+	headerXClientID = "X-Client-ID"
+)
+
+// Park Парк фейкового API. Если ClientID и APIKey заданы, запросы к парку проверяют авторизацию
+type Park struct {
+	ID       fleet.ParkID
+	Name     string
+	City     string
+	ClientID string
+	APIKey   string
+}
+
+type driverRecord struct {
+	profile   fleet.DriverProfile
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+type orderRecord struct {
+	order    fleet.Order
+	bookedAt time.Time
+}
+
+type transactionRecord struct {
+	transaction fleet.Transaction
+	eventAt     time.Time
+}
+
+type parkState struct {
+	park         Park
+	cars         []fleet.Vehicle
+	drivers      []driverRecord
+	orders       []orderRecord
+	transactions []transactionRecord
+}
+
+// Server Фейковый Fleet API. Данные хранятся в памяти, методы безопасны для конкурентного использования
+type Server struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	parks    map[fleet.ParkID]*parkState
+	faults   []*Fault
+	latency  time.Duration
+	requests map[string]int
+	now      func() time.Time
+}
+
+// NewServer Создает и запускает фейковый API. Сервер нужно остановить вызовом Close
+func NewServer() *Server {
+	s := &Server{
+		parks:    make(map[fleet.ParkID]*parkState),
+		requests: make(map[string]int),
+		now:      time.Now,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/parks/cars/list", s.handleCarsList)
+	mux.HandleFunc("POST /v1/parks/driver-profiles/list", s.handleDriverProfilesList)
+	mux.HandleFunc("POST /v1/parks/orders/list", s.handleOrdersList)
+	mux.HandleFunc("POST /v2/parks/transactions/list", s.handleTransactionsList)
+	mux.HandleFunc("POST /v2/parks/driver-profiles/transactions/list", s.handleTransactionsList)
+
+	s.server = httptest.NewServer(s.middleware(mux))
+	return s
+}
+
+// URL Адрес сервера для fleet.WithAPIHost
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close Останавливает сервер
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// SetClock Источник текущего времени для отметок обновления профилей
+func (s *Server) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// Requests Число запросов к методу API с путем endpoint, включая завершившиеся ошибкой
+func (s *Server) Requests(endpoint string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[endpoint]
+}
+
+// AddPark Добавляет или обновляет парк
+func (s *Server) AddPark(p Park) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if state, ok := s.parks[p.ID]; ok {
+		state.park = p
+		return
+	}
+	s.parks[p.ID] = &parkState{park: p}
+}
+
+// PutCar Добавляет ТС в парк или заменяет ТС с тем же идентификатором
+func (s *Server) PutCar(parkID fleet.ParkID, car fleet.Vehicle) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.parks[parkID]
+	if !ok {
+		return fmt.Errorf("park %s not found", parkID)
+	}
+
+	i := slices.IndexFunc(state.cars, func(v fleet.Vehicle) bool { return v.Id == car.Id })
+	if i >= 0 {
+		state.cars[i] = car
+	} else {
+		state.cars = append(state.cars, car)
+	}
+	return nil
+}
+
+// DeleteCar Удаляет ТС из парка
+func (s *Server) DeleteCar(parkID fleet.ParkID, carID fleet.CarID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if state, ok := s.parks[parkID]; ok {
+		state.cars = slices.DeleteFunc(state.cars, func(v fleet.Vehicle) bool { return v.Id == carID })
+	}
+}
+
+// PutDriver Добавляет профиль водителя в парк или заменяет профиль с тем же идентификатором.
+// Время обновления профиля устанавливается по часам сервера. Время создания, если в профиле не задан
+// CreatedDate, - время первого добавления
+func (s *Server) PutDriver(parkID fleet.ParkID, driver fleet.DriverProfile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.parks[parkID]
+	if !ok {
+		return fmt.Errorf("park %s not found", parkID)
+	}
+	if driver.Profile == nil || driver.Profile.Id == "" {
+		return fmt.Errorf("driver profile id is required")
+	}
+	driver.Profile.ParkId = parkID
+
+	record := driverRecord{profile: driver, createdAt: s.now(), updatedAt: s.now()}
+	if created, err := fleet.ParseTime(driver.Profile.CreatedDate); err == nil {
+		record.createdAt = created
+	}
+	i := slices.IndexFunc(state.drivers, func(r driverRecord) bool { return r.profile.Profile.Id == driver.Profile.Id })
+	if i >= 0 {
+		if driver.Profile.CreatedDate == "" {
+			record.createdAt = state.drivers[i].createdAt
+		}
+		state.drivers[i] = record
+	} else {
+		state.drivers = append(state.drivers, record)
+	}
+	return nil
+}
+
+// DeleteDriver Удаляет профиль водителя из парка
+func (s *Server) DeleteDriver(parkID fleet.ParkID, driverID fleet.DriverID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if state, ok := s.parks[parkID]; ok {
+		state.drivers = slices.DeleteFunc(state.drivers, func(r driverRecord) bool { return r.profile.Profile.Id == driverID })
+	}
+}
+
+// PutOrder Добавляет заказ в парк или заменяет заказ с тем же идентификатором. Время бронирования
+// BookedAt обязательно, по нему заказ фильтруется и упорядочивается в списке
+func (s *Server) PutOrder(parkID fleet.ParkID, order fleet.Order) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.parks[parkID]
+	if !ok {
+		return fmt.Errorf("park %s not found", parkID)
+	}
+	if order.Id == "" {
+		return fmt.Errorf("order id is required")
+	}
+	bookedAt, err := fleet.ParseTime(order.BookedAt)
+	if err != nil {
+		return fmt.Errorf("order %s: booked_at: %w", order.Id, err)
+	}
+
+	record := orderRecord{order: order, bookedAt: bookedAt}
+	i := slices.IndexFunc(state.orders, func(r orderRecord) bool { return r.order.Id == order.Id })
+	if i >= 0 {
+		state.orders[i] = record
+	} else {
+		state.orders = append(state.orders, record)
+	}
+	slices.SortFunc(state.orders, func(a, b orderRecord) int { return a.position().compare(b.position()) })
+	return nil
+}
+
+// DeleteOrder Удаляет заказ из парка
+func (s *Server) DeleteOrder(parkID fleet.ParkID, orderID fleet.OrderID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if state, ok := s.parks[parkID]; ok {
+		state.orders = slices.DeleteFunc(state.orders, func(r orderRecord) bool { return r.order.Id == orderID })
+	}
+}
+
+// AddTransaction Добавляет транзакцию в парк. Транзакции не изменяются, поэтому повторное добавление
+// транзакции с тем же идентификатором возвращает ошибку. Время EventAt обязательно
+func (s *Server) AddTransaction(parkID fleet.ParkID, tx fleet.Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.parks[parkID]
+	if !ok {
+		return fmt.Errorf("park %s not found", parkID)
+	}
+	if tx.Id == "" {
+		return fmt.Errorf("transaction id is required")
+	}
+	if slices.ContainsFunc(state.transactions, func(r transactionRecord) bool { return r.transaction.Id == tx.Id }) {
+		return fmt.Errorf("transaction %s already exists", tx.Id)
+	}
+	eventAt, err := fleet.ParseTime(tx.EventAt)
+	if err != nil {
+		return fmt.Errorf("transaction %s: event_at: %w", tx.Id, err)
+	}
+
+	state.transactions = append(state.transactions, transactionRecord{transaction: tx, eventAt: eventAt})
+	slices.SortFunc(state.transactions, func(a, b transactionRecord) int { return a.position().compare(b.position()) })
+	return nil
+}
+
+// authorize Находит парк запроса и проверяет авторизацию. Возвращает nil, если ответ с ошибкой уже отправлен
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request, parkID string) *parkState {
+	state, ok := s.parks[fleet.ParkID(parkID)]
+	if !ok {
+		writeError(w, http.StatusNotFound, "park_not_found", "park not found")
+		return nil
+	}
+
+	if state.park.APIKey != "" &&
+		(r.Header.Get(headerXAPIKey) != state.park.APIKey || r.Header.Get(headerXClientID) != state.park.ClientID) {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "invalid client id or api key")
+		return nil
+	}
+
+	return state
+}
+
+func (s *Server) handleCarsList(w http.ResponseWriter, r *http.Request) {
+	var req models.CarsListRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.authorize(w, r, req.Query.Park.Id)
+	if state == nil {
+		return
+	}
+
+	var matched []fleet.Vehicle
+	for _, car := range state.cars {
+		if matchCar(car, req.Query) {
+			matched = append(matched, car)
+		}
+	}
+
+	offset, limit, ok := pageBounds(w, req.Offset, req.Limit)
+	if !ok {
+		return
+	}
+
+	res := fleet.GetCarsListResult{
+		Total:  len(matched),
+		Offset: offset,
+		Limit:  limit,
+		Cars:   append([]fleet.Vehicle{}, page(matched, offset, limit)...),
+	}
+
+	writeJSON(w, res)
+}
+
+func (s *Server) handleDriverProfilesList(w http.ResponseWriter, r *http.Request) {
+	var req models.DriverProfilesRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.Query.Park == nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "query.park.id is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.authorize(w, r, req.Query.Park.Id)
+	if state == nil {
+		return
+	}
+
+	records, ok := sortDrivers(w, state.drivers, req.SortOrder)
+	if !ok {
+		return
+	}
+
+	withUpdatedAt := req.Fields != nil && req.Fields.UpdatedAt
+
+	var matched []fleet.DriverProfile
+	for _, record := range records {
+		if matchDriver(record, req.Query) {
+			profile := record.profile
+			if withUpdatedAt {
+				profile.UpdatedAt = record.updatedAt.UTC().Format(time.RFC3339Nano)
+			}
+			if req.Fields != nil {
+				profile = projectDriver(profile, req.Fields)
+			}
+			matched = append(matched, profile)
+		}
+	}
+
+	offset, limit, ok := pageBounds(w, req.Offset, req.Limit)
+	if !ok {
+		return
+	}
+
+	res := fleet.GetDriverProfilesResult{
+		Total:  len(matched),
+		Offset: offset,
+		Limit:  limit,
+		Parks: []fleet.DriverProfilePark{{
+			Id:   state.park.ID,
+			City: state.park.City,
+			Name: state.park.Name,
+		}},
+		DriverProfiles: append([]fleet.DriverProfile{}, page(matched, offset, limit)...),
+	}
+
+	writeJSON(w, res)
+}
+
+func (s *Server) handleOrdersList(w http.ResponseWriter, r *http.Request) {
+	var req models.OrdersListRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if !cursorPageLimit(w, req.Limit, maxOrderPageLimit) {
+		return
+	}
+	from, to, ok := parseInterval(w, req.Query.Park.Order.BookedAt, "query.park.order.booked_at")
+	if !ok {
+		return
+	}
+	after, ok := parseCursor(w, req.Cursor)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.authorize(w, r, req.Query.Park.Id)
+	if state == nil {
+		return
+	}
+
+	// Курсор указывает на последний отданный заказ, поэтому вставки и удаления не сдвигают страницы
+	var matched []orderRecord
+	for _, record := range state.orders {
+		if record.bookedAt.Before(from) || !record.bookedAt.Before(to) {
+			continue
+		}
+		if statuses := req.Query.Park.Order.Statuses; len(statuses) > 0 && !slices.Contains(statuses, record.order.Status) {
+			continue
+		}
+		if after != nil && record.position().compare(*after) <= 0 {
+			continue
+		}
+		matched = append(matched, record)
+	}
+
+	res := fleet.GetOrdersListResult{
+		Limit:  req.Limit,
+		Orders: []fleet.Order{},
+	}
+	for _, record := range page(matched, 0, req.Limit) {
+		res.Orders = append(res.Orders, record.order)
+	}
+	if len(matched) > req.Limit {
+		res.Cursor = matched[req.Limit-1].position().cursor()
+	}
+
+	writeJSON(w, res)
+}
+
+func (s *Server) handleTransactionsList(w http.ResponseWriter, r *http.Request) {
+	var req models.TransactionsListRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	var driverID fleet.DriverID
+	if r.URL.Path == fleet.EndpointDriverTransactions {
+		if req.Query.Park.DriverProfile == nil || req.Query.Park.DriverProfile.Id == "" {
+			writeError(w, http.StatusBadRequest, "bad_request", "query.park.driver_profile.id is required")
+			return
+		}
+		driverID = fleet.DriverID(req.Query.Park.DriverProfile.Id)
+	}
+
+	if !cursorPageLimit(w, req.Limit, maxPageLimit) {
+		return
+	}
+	from, to, ok := parseInterval(w, req.Query.Park.Transaction.EventAt, "query.park.transaction.event_at")
+	if !ok {
+		return
+	}
+	after, ok := parseCursor(w, req.Cursor)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.authorize(w, r, req.Query.Park.Id)
+	if state == nil {
+		return
+	}
+
+	var matched []transactionRecord
+	for _, record := range state.transactions {
+		if record.eventAt.Before(from) || !record.eventAt.Before(to) {
+			continue
+		}
+		if driverID != "" && record.transaction.DriverProfileId != driverID {
+			continue
+		}
+		if ids := req.Query.Park.Transaction.CategoryIds; len(ids) > 0 && !slices.Contains(ids, record.transaction.CategoryId) {
+			continue
+		}
+		if after != nil && record.position().compare(*after) <= 0 {
+			continue
+		}
+		matched = append(matched, record)
+	}
+
+	res := fleet.GetTransactionsListResult{Transactions: []fleet.Transaction{}}
+	for _, record := range page(matched, 0, req.Limit) {
+		res.Transactions = append(res.Transactions, record.transaction)
+	}
+	if len(matched) > req.Limit {
+		res.Cursor = matched[req.Limit-1].position().cursor()
+	}
+
+	writeJSON(w, res)
+}
+
+// listPosition Позиция элемента в списках с курсором: по времени, затем по идентификатору
+type listPosition struct {
+	at time.Time
+	id string
+}
+
+func (r orderRecord) position() listPosition {
+	return listPosition{at: r.bookedAt, id: string(r.order.Id)}
+}
+
+func (r transactionRecord) position() listPosition {
+	return listPosition{at: r.eventAt, id: string(r.transaction.Id)}
+}
+
+func (p listPosition) compare(other listPosition) int {
+	if c := p.at.Compare(other.at); c != 0 {
+		return c
+	}
+	return strings.Compare(p.id, other.id)
+}
+
+// cursor Курсор, указывающий на элемент с этой позицией
+func (p listPosition) cursor() string {
+	key := p.at.UTC().Format(time.RFC3339Nano) + "|" + p.id
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// parseCursor Разбирает курсор запроса. Возвращает nil для первой страницы
+func parseCursor(w http.ResponseWriter, cursor string) (*listPosition, bool) {
+	if cursor == "" {
+		return nil, true
+	}
+
+	invalid := func() (*listPosition, bool) {
+		writeError(w, http.StatusBadRequest, "bad_request", "invalid cursor")
+		return nil, false
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return invalid()
+	}
+	at, id, ok := strings.Cut(string(data), "|")
+	if !ok {
+		return invalid()
+	}
+	t, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return invalid()
+	}
+	return &listPosition{at: t, id: id}, true
+}
+
+func parseInterval(w http.ResponseWriter, interval models.TimeInterval, field string) (time.Time, time.Time, bool) {
+	from, errFrom := time.Parse(time.RFC3339Nano, interval.From)
+	to, errTo := time.Parse(time.RFC3339Nano, interval.To)
+	if errFrom != nil || errTo != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", field+".from and to are required")
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
+
+func cursorPageLimit(w http.ResponseWriter, limit, maxLimit int) bool {
+	if limit < 1 || limit > maxLimit {
+		writeError(w, http.StatusBadRequest, "bad_request", fmt.Sprintf("limit must be in [1, %d]", maxLimit))
+		return false
+	}
+	return true
+}
+
+func matchCar(car fleet.Vehicle, query models.CarsListQuery) bool {
+	if f := query.Park.Car; f != nil {
+		if len(f.Id) > 0 && !slices.Contains(f.Id, string(car.Id)) {
+			return false
+		}
+		if len(f.Status) > 0 && !slices.Contains(f.Status, car.Status) {
+			return false
+		}
+		for _, c := range f.Categories {
+			if !slices.Contains(car.Category, c) {
+				return false
+			}
+		}
+		for _, a := range f.Amenities {
+			if !slices.Contains(car.Amenities, a) {
+				return false
+			}
+		}
+	}
+
+	return matchText(query.Text, car.Brand, car.Model, car.Number, car.Callsign, car.Vin)
+}
+
+// sortDrivers Упорядочивает профили по полям sort_order. Профили с равными значениями полей остаются
+// в порядке добавления
+func sortDrivers(w http.ResponseWriter, drivers []driverRecord, order []models.DriverProfileRequestSortOrderField) ([]driverRecord, bool) {
+	compare := make([]func(a, b driverRecord) int, 0, len(order))
+	for _, o := range order {
+		var cmp func(a, b driverRecord) int
+		switch o.Field {
+		case "driver_profile.id":
+			cmp = func(a, b driverRecord) int {
+				return strings.Compare(string(a.profile.Profile.Id), string(b.profile.Profile.Id))
+			}
+		case "driver_profile.created_date":
+			cmp = func(a, b driverRecord) int { return a.createdAt.Compare(b.createdAt) }
+		case "driver_profile.updated_at":
+			cmp = func(a, b driverRecord) int { return a.updatedAt.Compare(b.updatedAt) }
+		default:
+			writeError(w, http.StatusBadRequest, "bad_request", fmt.Sprintf("unsupported sort_order field %q", o.Field))
+			return nil, false
+		}
+
+		switch o.Direction {
+		case "asc":
+			compare = append(compare, cmp)
+		case "desc":
+			compare = append(compare, func(a, b driverRecord) int { return cmp(b, a) })
+		default:
+			writeError(w, http.StatusBadRequest, "bad_request", fmt.Sprintf("unsupported sort_order direction %q", o.Direction))
+			return nil, false
+		}
+	}
+
+	sorted := slices.Clone(drivers)
+	slices.SortStableFunc(sorted, func(a, b driverRecord) int {
+		for _, cmp := range compare {
+			if c := cmp(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return sorted, true
+}
+
+func matchDriver(record driverRecord, query models.DriverProfilesListRequestQuery) bool {
+	profile := record.profile
+	park := query.Park
+
+	if f := park.DriverProfile; f != nil {
+		if len(f.Id) > 0 && !slices.Contains(f.Id, string(profile.Profile.Id)) {
+			return false
+		}
+		if len(f.WorkRuleID) > 0 && !slices.Contains(f.WorkRuleID, profile.Profile.WorkRuleId) {
+			return false
+		}
+		if len(f.WorkStatus) > 0 && !slices.Contains(f.WorkStatus, profile.Profile.WorkStatus) {
+			return false
+		}
+	}
+
+	if f := park.CurrentStatus; f != nil && len(f.Status) > 0 {
+		if profile.CurrentStatus == nil || !slices.Contains(f.Status, profile.CurrentStatus.Status) {
+			return false
+		}
+	}
+
+	if f := park.UpdatedAt; f != nil {
+		if from, err := time.Parse(time.RFC3339Nano, f.From); err == nil && record.updatedAt.Before(from) {
+			return false
+		}
+		if to, err := time.Parse(time.RFC3339Nano, f.To); err == nil && !record.updatedAt.Before(to) {
+			return false
+		}
+	}
+
+	fields := []string{
+		profile.Profile.FirstName,
+		profile.Profile.LastName,
+		profile.Profile.MiddleName,
+		profile.Profile.DriverLicense.Number,
+		profile.Profile.DriverLicense.NormalizedNumber,
+	}
+	fields = append(fields, profile.Profile.Phones...)
+	if profile.Car != nil {
+		fields = append(fields, profile.Car.Number, profile.Car.Callsign)
+	}
+
+	return matchText(query.Text, fields...)
+}
+
+// projectDriver Оставляет в профиле только запрошенные поля. Пустой список полей исключает блок целиком
+func projectDriver(profile fleet.DriverProfile, fields *models.DriverProfileListRequestFields) fleet.DriverProfile {
+	data, _ := json.Marshal(profile)
+	var doc map[string]any
+	_ = json.Unmarshal(data, &doc)
+
+	sections := map[string]*[]string{
+		"accounts":       fields.Account,
+		"car":            fields.Car,
+		"current_status": fields.CurrentStatus,
+		"driver_profile": fields.DriverProfile,
+	}
+	for section, keep := range sections {
+		if keep == nil {
+			continue
+		}
+		if len(*keep) == 0 {
+			delete(doc, section)
+			continue
+		}
+
+		switch v := doc[section].(type) {
+		case map[string]any:
+			keepKeys(v, *keep)
+		case []any:
+			for _, item := range v {
+				if m, ok := item.(map[string]any); ok {
+					keepKeys(m, *keep)
+				}
+			}
+		}
+	}
+
+	data, _ = json.Marshal(doc)
+	var projected fleet.DriverProfile
+	_ = json.Unmarshal(data, &projected)
+	return projected
+}
+
+func keepKeys(m map[string]any, keys []string) {
+	for key := range m {
+		if !slices.Contains(keys, key) {
+			delete(m, key)
+		}
+	}
+}
+
+func matchText(text string, fields ...string) bool {
+	if text == "" {
+		return true
+	}
+	text = strings.ToLower(text)
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), text) {
+			return true
+		}
+	}
+	return false
+}
+
+func pageBounds(w http.ResponseWriter, offset, limit int) (int, int, bool) {
+	if offset < 0 || limit < 1 || limit > maxPageLimit {
+		writeError(w, http.StatusBadRequest, "bad_request", fmt.Sprintf("limit must be in [1, %d], offset must not be negative", maxPageLimit))
+		return 0, 0, false
+	}
+	return offset, limit, true
+}
+
+func page[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	return items[offset:min(offset+limit, len(items))]
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(models.ErrorResponse{Code: code, Message: message})
+}
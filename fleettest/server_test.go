@@ -0,0 +1,368 @@
+package fleettest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	fleet "github.com/sinland/yandex-taxi-go"
+	"github.com/sinland/yandex-taxi-go/internal/models"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const (
+	testParkID   fleet.ParkID = "park-1"
+	testClientID              = "client-1"
+	testAPIKey                = "key-1"
+)
+
+func newTestServer(t *testing.T) *Server {
+	s := NewServer()
+	t.Cleanup(s.Close)
+
+	s.AddPark(Park{ID: testParkID, Name: "Park", City: "Москва", ClientID: testClientID, APIKey: testAPIKey})
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, s.PutCar(testParkID, fleet.Vehicle{
+			Id:     fleet.CarID(fmt.Sprintf("car-%d", i)),
+			Brand:  "Kia",
+			Model:  "Rio",
+			Number: fmt.Sprintf("А00%dАА77", i),
+			Status: "working",
+		}))
+	}
+
+	for i, name := range []string{"Ivanov", "Petrov", "Sidorov"} {
+		status := "online"
+		if i == 2 {
+			status = "offline"
+		}
+		require.NoError(t, s.PutDriver(testParkID, fleet.DriverProfile{
+			Profile: &fleet.DriverProfileData{
+				Id:         fleet.DriverID(fmt.Sprintf("driver-%d", i)),
+				LastName:   name,
+				Phones:     []string{fmt.Sprintf("+7999000000%d", i)},
+				WorkStatus: "working",
+			},
+			CurrentStatus: &fleet.DriverProfileCurrentStatus{Status: status},
+		}))
+	}
+
+	return s
+}
+
+func newTestClient(s *Server, opts ...fleet.ClientOption) *fleet.Client {
+	opts = append([]fleet.ClientOption{fleet.WithAPIHost(s.URL())}, opts...)
+	return fleet.NewClient(fleet.ClientConfig{ClientID: testClientID, APIKey: testAPIKey}, opts...)
+}
+
+func TestServer_CarsList(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestServer(t)
+	c := newTestClient(s)
+
+	res, err := c.GetCarsList(ctx, fleet.GetCarsListArgs{ParkID: testParkID, Page: 1, Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, 5, res.Total)
+	require.Equal(t, 2, res.Offset)
+	require.Len(t, res.Cars, 2)
+	require.Equal(t, fleet.CarID("car-2"), res.Cars[0].Id)
+
+	res, err = c.GetCarsList(ctx, fleet.GetCarsListArgs{ParkID: testParkID, Page: 2, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, res.Cars, 1)
+
+	require.NoError(t, s.PutCar(testParkID, fleet.Vehicle{Id: "car-4", Brand: "Skoda"}))
+	s.DeleteCar(testParkID, "car-0")
+
+	res, err = c.GetCarsList(ctx, fleet.GetCarsListArgs{ParkID: testParkID})
+	require.NoError(t, err)
+	require.Equal(t, 4, res.Total)
+	require.Equal(t, "Skoda", res.Cars[3].Brand)
+
+	_, err = c.GetCarsList(ctx, fleet.GetCarsListArgs{ParkID: "unknown"})
+	var apiErr *fleet.APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+
+	require.Equal(t, 4, s.Requests("/v1/parks/cars/list"))
+}
+
+func TestServer_DriverProfilesList(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestServer(t)
+	c := newTestClient(s)
+
+	res, err := c.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{ParkID: testParkID, QueryText: "petr"})
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Total)
+	require.Equal(t, fleet.DriverID("driver-1"), res.DriverProfiles[0].Profile.Id)
+	require.Equal(t, testParkID, res.DriverProfiles[0].Profile.ParkId)
+	require.Equal(t, []fleet.DriverProfilePark{{Id: testParkID, City: "Москва", Name: "Park"}}, res.Parks)
+
+	res, err = c.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{ParkID: testParkID, Offset: 1, Limit: 1})
+	require.NoError(t, err)
+	require.Equal(t, 3, res.Total)
+	require.Len(t, res.DriverProfiles, 1)
+	require.Equal(t, fleet.DriverID("driver-1"), res.DriverProfiles[0].Profile.Id)
+
+	body, _ := json.Marshal(models.DriverProfilesRequest{
+		Limit: 10,
+		Query: models.DriverProfilesListRequestQuery{
+			Park: &models.DriverProfilesListRequestQueryPark{
+				Id:            string(testParkID),
+				CurrentStatus: &models.DriverProfilesListRequestQueryParkCurrentStatus{Status: []string{"offline"}},
+			},
+		},
+	})
+	req, _ := http.NewRequest(http.MethodPost, s.URL()+"/v1/parks/driver-profiles/list", bytes.NewReader(body))
+	req.Header.Set("X-Client-ID", testClientID)
+	req.Header.Set("X-API-Key", testAPIKey)
+	httpRes, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer httpRes.Body.Close()
+
+	var raw fleet.GetDriverProfilesResult
+	require.NoError(t, json.NewDecoder(httpRes.Body).Decode(&raw))
+	require.Equal(t, 1, raw.Total)
+	require.Equal(t, fleet.DriverID("driver-2"), raw.DriverProfiles[0].Profile.Id)
+}
+
+func TestServer_DriverProfilesSortOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestServer(t)
+	c := newTestClient(s)
+
+	ids := func(res *fleet.GetDriverProfilesResult) []fleet.DriverID {
+		var out []fleet.DriverID
+		for _, p := range res.DriverProfiles {
+			out = append(out, p.Profile.Id)
+		}
+		return out
+	}
+
+	res, err := c.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{
+		ParkID:    testParkID,
+		SortOrder: []fleet.DriverProfileSortOrder{{Field: "driver_profile.id", Direction: "desc"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []fleet.DriverID{"driver-2", "driver-1", "driver-0"}, ids(res))
+
+	// Обновление сдвигает профиль в конец порядка по updated_at, но не меняет порядок по created_date
+	later := time.Now().Add(time.Hour)
+	s.SetClock(func() time.Time { return later })
+	require.NoError(t, s.PutDriver(testParkID, fleet.DriverProfile{Profile: &fleet.DriverProfileData{Id: "driver-1", LastName: "Петров"}}))
+
+	res, err = c.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{
+		ParkID:    testParkID,
+		SortOrder: []fleet.DriverProfileSortOrder{{Field: "driver_profile.updated_at", Direction: "asc"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []fleet.DriverID{"driver-0", "driver-2", "driver-1"}, ids(res))
+
+	res, err = c.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{
+		ParkID:    testParkID,
+		SortOrder: []fleet.DriverProfileSortOrder{{Field: "driver_profile.created_date", Direction: "asc"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []fleet.DriverID{"driver-0", "driver-1", "driver-2"}, ids(res))
+
+	_, err = c.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{
+		ParkID:    testParkID,
+		SortOrder: []fleet.DriverProfileSortOrder{{Field: "driver_profile.rating", Direction: "asc"}},
+	})
+	var apiErr *fleet.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+}
+
+func TestServer_Auth(t *testing.T) {
+	t.Parallel()
+
+	s := newTestServer(t)
+	c := fleet.NewClient(fleet.ClientConfig{ClientID: testClientID, APIKey: "wrong"}, fleet.WithAPIHost(s.URL()))
+
+	_, err := c.GetCarsList(context.Background(), fleet.GetCarsListArgs{ParkID: testParkID})
+	var apiErr *fleet.APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+}
+
+func TestServer_Faults(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("rate limited then recovered", func(t *testing.T) {
+		t.Parallel()
+
+		s := newTestServer(t)
+		s.InjectFault(Fault{Endpoint: "/v1/parks/cars/list", Status: http.StatusTooManyRequests, Times: 2})
+
+		c := newTestClient(s, fleet.WithRetry(fleet.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+
+		res, err := c.GetCarsList(ctx, fleet.GetCarsListArgs{ParkID: testParkID})
+		require.NoError(t, err)
+		require.Equal(t, 5, res.Total)
+		require.Equal(t, 3, s.Requests("/v1/parks/cars/list"))
+
+		_, err = c.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{ParkID: testParkID})
+		require.NoError(t, err)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		t.Parallel()
+
+		s := newTestServer(t)
+		s.InjectFault(Fault{Status: http.StatusServiceUnavailable})
+
+		c := newTestClient(s)
+
+		_, err := c.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{ParkID: testParkID})
+		require.EqualError(t, err, "[503] Service Unavailable (503)")
+
+		s.ClearFaults()
+		_, err = c.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{ParkID: testParkID})
+		require.NoError(t, err)
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		t.Parallel()
+
+		s := newTestServer(t)
+		s.SetLatency(200 * time.Millisecond)
+
+		c := newTestClient(s)
+
+		_, err := c.GetCarsList(ctx, fleet.GetCarsListArgs{ParkID: testParkID}, fleet.WithCallTimeout(20*time.Millisecond))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestServer_DriverProfilesProjection(t *testing.T) {
+	t.Parallel()
+
+	s := newTestServer(t)
+	c := newTestClient(s)
+
+	res, err := c.GetDriverProfiles(context.Background(), fleet.GetDriverProfilesArgs{
+		ParkID: testParkID,
+		Fields: &fleet.DriverProfileFields{
+			Account:       []string{},
+			Car:           []string{},
+			CurrentStatus: []string{"status"},
+			DriverProfile: []string{"id"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.DriverProfiles, 3)
+	require.Equal(t, fleet.DriverProfile{
+		CurrentStatus: &fleet.DriverProfileCurrentStatus{Status: "online"},
+		Profile:       &fleet.DriverProfileData{Id: "driver-0"},
+	}, res.DriverProfiles[0])
+}
+
+func TestServer_OrdersList(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestServer(t)
+	c := newTestClient(s)
+
+	base := time.Date(2024, time.March, 3, 10, 0, 0, 0, time.UTC)
+	for i, status := range []string{"complete", "cancelled", "complete", "complete"} {
+		require.NoError(t, s.PutOrder(testParkID, fleet.Order{
+			Id:       fleet.OrderID(fmt.Sprintf("order-%d", i)),
+			Status:   status,
+			BookedAt: base.Add(time.Duration(i) * time.Minute).Format(time.RFC3339),
+		}))
+	}
+
+	args := fleet.GetOrdersListArgs{
+		ParkID:   testParkID,
+		Limit:    1,
+		BookedAt: fleet.TimeRange{From: base, To: base.Add(3 * time.Minute)},
+		Statuses: []string{"complete"},
+	}
+	res, err := c.GetOrdersList(ctx, args)
+	require.NoError(t, err)
+	require.Len(t, res.Orders, 1)
+	require.Equal(t, fleet.OrderID("order-0"), res.Orders[0].Id)
+	require.NotEmpty(t, res.Cursor)
+
+	// Заказ перед курсором не сдвигает следующую страницу
+	require.NoError(t, s.PutOrder(testParkID, fleet.Order{Id: "early", Status: "complete", BookedAt: base.Format(time.RFC3339)}))
+
+	args.Cursor = res.Cursor
+	res, err = c.GetOrdersList(ctx, args)
+	require.NoError(t, err)
+	require.Len(t, res.Orders, 1)
+	require.Equal(t, fleet.OrderID("order-2"), res.Orders[0].Id)
+	require.Empty(t, res.Cursor)
+
+	args.Cursor = "%%%"
+	_, err = c.GetOrdersList(ctx, args)
+	var apiErr *fleet.APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+}
+
+func TestServer_TransactionsList(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestServer(t)
+	c := newTestClient(s)
+
+	base := time.Date(2024, time.March, 3, 10, 0, 0, 0, time.UTC)
+	for i, driverID := range []fleet.DriverID{"driver-0", "driver-1", "driver-0"} {
+		require.NoError(t, s.AddTransaction(testParkID, fleet.Transaction{
+			Id:              fleet.TransactionID(fmt.Sprintf("tx-%d", i)),
+			EventAt:         base.Add(time.Duration(i) * time.Minute).Format(time.RFC3339),
+			CategoryId:      "partner_service_manual",
+			Amount:          "-100.00",
+			DriverProfileId: driverID,
+		}))
+	}
+	require.Error(t, s.AddTransaction(testParkID, fleet.Transaction{Id: "tx-0", EventAt: base.Format(time.RFC3339)}))
+
+	args := fleet.GetTransactionsListArgs{
+		ParkID:  testParkID,
+		Limit:   2,
+		EventAt: fleet.TimeRange{From: base, To: base.Add(time.Hour)},
+	}
+	res, err := c.GetParkTransactions(ctx, args)
+	require.NoError(t, err)
+	require.Len(t, res.Transactions, 2)
+	require.NotEmpty(t, res.Cursor)
+
+	args.Cursor = res.Cursor
+	res, err = c.GetParkTransactions(ctx, args)
+	require.NoError(t, err)
+	require.Len(t, res.Transactions, 1)
+	require.Equal(t, fleet.TransactionID("tx-2"), res.Transactions[0].Id)
+	require.Empty(t, res.Cursor)
+
+	args.Cursor = ""
+	args.DriverID = "driver-0"
+	res, err = c.GetDriverTransactions(ctx, args)
+	require.NoError(t, err)
+	require.Len(t, res.Transactions, 2)
+	require.Equal(t, fleet.TransactionID("tx-2"), res.Transactions[1].Id)
+
+	args.DriverID = ""
+	_, err = c.GetDriverTransactions(ctx, args)
+	var apiErr *fleet.APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+}
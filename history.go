@@ -0,0 +1,376 @@
+package yandex_taxi_go
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HistoryEntity Вид сущности в журнале истории
+type HistoryEntity string
+
+const (
+	HistoryVehicle       HistoryEntity = "vehicle"        // Vehicle, идентификатор - CarID
+	HistoryDriverProfile HistoryEntity = "driver_profile" // DriverProfileData, идентификатор - DriverID
+	HistoryCarBinding    HistoryEntity = "car_binding"    // CarBinding, идентификатор - DriverID
+)
+
+// CarBinding Привязка ТС к водителю
+type CarBinding struct {
+	DriverID DriverID `json:"driver_id"`
+	CarID    CarID    `json:"car_id"`
+}
+
+// HistoryEvent Наблюденное изменение сущности
+type HistoryEvent struct {
+	Seq     int64           `json:"seq"`               // Порядковый номер события в журнале
+	At      time.Time       `json:"at"`                // Время наблюдения, с которого действует состояние
+	Entity  HistoryEntity   `json:"entity"`            // Вид сущности
+	ID      string          `json:"id"`                // Идентификатор сущности
+	Deleted bool            `json:"deleted,omitempty"` // Сущность удалена или привязка снята
+	State   json.RawMessage `json:"state,omitempty"`   // Полное состояние сущности в формате API
+	Changes []FieldChange   `json:"changes,omitempty"` // Изменения относительно предыдущего состояния. Для первого состояния не заполняется
+}
+
+// HistoryLog Журнал событий истории, в который события только дописываются
+type HistoryLog interface {
+	// Append Дописывает событие в журнал
+	Append(ctx context.Context, event HistoryEvent) error
+	// Replay Передает в fn все события журнала в порядке записи
+	Replay(ctx context.Context, fn func(HistoryEvent) error) error
+}
+
+// History История состояний ТС, профилей водителей и привязок ТС к водителям, построенная по журналу
+// событий. Наблюдения записываются в журнал, только если состояние изменилось, и отвечают на
+// вопрос "каким было состояние на момент T". Профиль водителя хранится без ТС, текущего состояния
+// и счетов: ТС записывается отдельно как Vehicle и привязка CarBinding, а текущее состояние и баланс
+// меняются слишком часто для истории.
+// Наблюдения одной сущности нужно передавать в порядке времени: изменение определяется
+// сравнением с состоянием на момент наблюдения
+type History struct {
+	log HistoryLog
+
+	mu        sync.RWMutex
+	seq       int64
+	timelines map[HistoryEntity]map[string][]HistoryEvent
+}
+
+// NewHistory Создает историю и восстанавливает ее из журнала log
+func NewHistory(ctx context.Context, log HistoryLog) (*History, error) {
+	h := &History{
+		log:       log,
+		timelines: make(map[HistoryEntity]map[string][]HistoryEvent),
+	}
+
+	err := log.Replay(ctx, func(event HistoryEvent) error {
+		h.index(event)
+		h.seq = max(h.seq, event.Seq)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("replay history: %w", err)
+	}
+	return h, nil
+}
+
+// index Добавляет событие в хронологию сущности, сохраняя порядок по времени и номеру
+func (h *History) index(event HistoryEvent) {
+	if h.timelines[event.Entity] == nil {
+		h.timelines[event.Entity] = make(map[string][]HistoryEvent)
+	}
+
+	timeline := h.timelines[event.Entity][event.ID]
+	i := sort.Search(len(timeline), func(i int) bool { return compareHistoryEvents(timeline[i], event) > 0 })
+	h.timelines[event.Entity][event.ID] = slices.Insert(timeline, i, event)
+}
+
+func compareHistoryEvents(a, b HistoryEvent) int {
+	if c := a.At.Compare(b.At); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Seq, b.Seq)
+}
+
+// eventAt Последнее событие сущности не позже t. Вызывается под h.mu
+func (h *History) eventAt(entity HistoryEntity, id string, t time.Time) (HistoryEvent, bool) {
+	timeline := h.timelines[entity][id]
+	i := sort.Search(len(timeline), func(i int) bool { return timeline[i].At.After(t) })
+	if i == 0 {
+		return HistoryEvent{}, false
+	}
+	return timeline[i-1], true
+}
+
+// ObserveVehicle Записывает состояние ТС на момент at. Возвращает записанные события
+func (h *History) ObserveVehicle(ctx context.Context, at time.Time, v Vehicle) ([]HistoryEvent, error) {
+	if v.Id == "" {
+		return nil, errors.New("vehicle id is required")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.observe(ctx, nil, at, HistoryVehicle, string(v.Id), v)
+}
+
+// ObserveDriverProfile Записывает состояние профиля водителя на момент at, а также его ТС и привязку ТС.
+// Профиль без ТС снимает привязку. Возвращает записанные события
+func (h *History) ObserveDriverProfile(ctx context.Context, at time.Time, p DriverProfile) ([]HistoryEvent, error) {
+	if p.Profile == nil || p.Profile.Id == "" {
+		return nil, errors.New("driver profile id is required")
+	}
+	id := string(p.Profile.Id)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	events, err := h.observe(ctx, nil, at, HistoryDriverProfile, id, p.Profile)
+	if err != nil {
+		return events, err
+	}
+
+	if p.Car == nil || p.Car.Id == "" {
+		return h.remove(ctx, events, at, HistoryCarBinding, id)
+	}
+
+	if events, err = h.observe(ctx, events, at, HistoryVehicle, string(p.Car.Id), p.Car); err != nil {
+		return events, err
+	}
+	return h.observe(ctx, events, at, HistoryCarBinding, id, CarBinding{DriverID: p.Profile.Id, CarID: p.Car.Id})
+}
+
+// ObserveVehicleRemoved Записывает удаление ТС на момент at
+func (h *History) ObserveVehicleRemoved(ctx context.Context, at time.Time, id CarID) ([]HistoryEvent, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.remove(ctx, nil, at, HistoryVehicle, string(id))
+}
+
+// ObserveDriverRemoved Записывает удаление профиля водителя и снятие его привязки ТС на момент at
+func (h *History) ObserveDriverRemoved(ctx context.Context, at time.Time, id DriverID) ([]HistoryEvent, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	events, err := h.remove(ctx, nil, at, HistoryDriverProfile, string(id))
+	if err != nil {
+		return events, err
+	}
+	return h.remove(ctx, events, at, HistoryCarBinding, string(id))
+}
+
+// observe Записывает состояние, если оно отличается от состояния на момент at. Вызывается под h.mu
+func (h *History) observe(ctx context.Context, events []HistoryEvent, at time.Time, entity HistoryEntity, id string, v any) ([]HistoryEvent, error) {
+	state, err := json.Marshal(v)
+	if err != nil {
+		return events, err
+	}
+
+	event := HistoryEvent{At: at, Entity: entity, ID: id, State: state}
+	if previous, ok := h.eventAt(entity, id, at); ok && !previous.Deleted {
+		if bytes.Equal(previous.State, state) {
+			return events, nil
+		}
+		if event.Changes, err = diffFields(previous.State, json.RawMessage(state)); err != nil {
+			return events, err
+		}
+	}
+	return h.append(ctx, events, event)
+}
+
+// remove Записывает удаление, если сущность существовала на момент at. Вызывается под h.mu
+func (h *History) remove(ctx context.Context, events []HistoryEvent, at time.Time, entity HistoryEntity, id string) ([]HistoryEvent, error) {
+	if previous, ok := h.eventAt(entity, id, at); !ok || previous.Deleted {
+		return events, nil
+	}
+	return h.append(ctx, events, HistoryEvent{At: at, Entity: entity, ID: id, Deleted: true})
+}
+
+func (h *History) append(ctx context.Context, events []HistoryEvent, event HistoryEvent) ([]HistoryEvent, error) {
+	event.Seq = h.seq + 1
+	if err := h.log.Append(ctx, event); err != nil {
+		return events, err
+	}
+	h.seq = event.Seq
+	h.index(event)
+	return append(events, event), nil
+}
+
+// StateAt Состояние сущности на момент t в формате API. found равен false, если сущность
+// на этот момент не наблюдалась или была удалена
+func (h *History) StateAt(entity HistoryEntity, id string, t time.Time) (state json.RawMessage, found bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	event, ok := h.eventAt(entity, id, t)
+	if !ok || event.Deleted {
+		return nil, false
+	}
+	return slices.Clone(event.State), true
+}
+
+// VehicleAt Состояние ТС на момент t
+func (h *History) VehicleAt(id CarID, t time.Time) (Vehicle, bool, error) {
+	var v Vehicle
+	found, err := h.decodeAt(HistoryVehicle, string(id), t, &v)
+	return v, found, err
+}
+
+// DriverProfileAt Профиль водителя на момент t
+func (h *History) DriverProfileAt(id DriverID, t time.Time) (DriverProfileData, bool, error) {
+	var p DriverProfileData
+	found, err := h.decodeAt(HistoryDriverProfile, string(id), t, &p)
+	return p, found, err
+}
+
+// CarAt ТС, привязанное к водителю на момент t
+func (h *History) CarAt(driverID DriverID, t time.Time) (CarID, bool, error) {
+	var b CarBinding
+	found, err := h.decodeAt(HistoryCarBinding, string(driverID), t, &b)
+	return b.CarID, found, err
+}
+
+// DriversAt Водители, к которым ТС было привязано на момент t, в порядке идентификаторов
+func (h *History) DriversAt(carID CarID, t time.Time) ([]DriverID, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var drivers []DriverID
+	for id := range h.timelines[HistoryCarBinding] {
+		event, ok := h.eventAt(HistoryCarBinding, id, t)
+		if !ok || event.Deleted {
+			continue
+		}
+		var b CarBinding
+		if err := json.Unmarshal(event.State, &b); err != nil {
+			return nil, fmt.Errorf("decode %s %s: %w", HistoryCarBinding, id, err)
+		}
+		if b.CarID == carID {
+			drivers = append(drivers, b.DriverID)
+		}
+	}
+	slices.Sort(drivers)
+	return drivers, nil
+}
+
+// Timeline Все события сущности в порядке времени
+func (h *History) Timeline(entity HistoryEntity, id string) []HistoryEvent {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return slices.Clone(h.timelines[entity][id])
+}
+
+func (h *History) decodeAt(entity HistoryEntity, id string, t time.Time, v any) (bool, error) {
+	state, found := h.StateAt(entity, id, t)
+	if !found {
+		return false, nil
+	}
+	if err := json.Unmarshal(state, v); err != nil {
+		return false, fmt.Errorf("decode %s %s: %w", entity, id, err)
+	}
+	return true, nil
+}
+
+// MemoryHistoryLog Журнал истории в памяти
+type MemoryHistoryLog struct {
+	mu     sync.Mutex
+	events []HistoryEvent
+}
+
+// NewMemoryHistoryLog Создает журнал истории в памяти
+func NewMemoryHistoryLog() *MemoryHistoryLog {
+	return &MemoryHistoryLog{}
+}
+
+// Append Дописывает событие в журнал
+func (l *MemoryHistoryLog) Append(_ context.Context, event HistoryEvent) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events = append(l.events, event)
+	return nil
+}
+
+// Replay Передает в fn все события журнала
+func (l *MemoryHistoryLog) Replay(ctx context.Context, fn func(HistoryEvent) error) error {
+	l.mu.Lock()
+	events := slices.Clone(l.events)
+	l.mu.Unlock()
+
+	for _, event := range events {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FileHistoryLog Журнал истории в файле формата JSON Lines. Оборванная последняя строка,
+// оставшаяся после сбоя во время записи, при открытии отбрасывается
+type FileHistoryLog struct {
+	mu   sync.Mutex
+	path string
+	log  *jsonlFile
+}
+
+// OpenFileHistoryLog Открывает журнал в файле path, создавая файл при необходимости
+func OpenFileHistoryLog(path string) (*FileHistoryLog, error) {
+	log, err := openJSONL(path, func(line []byte) error {
+		var event HistoryEvent
+		return json.Unmarshal(line, &event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &FileHistoryLog{path: path, log: log}, nil
+}
+
+// Append Дописывает событие в файл
+func (l *FileHistoryLog) Append(_ context.Context, event HistoryEvent) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.log.append(event)
+}
+
+// Replay Читает события из файла и передает их в fn
+func (l *FileHistoryLog) Replay(ctx context.Context, fn func(HistoryEvent) error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Файл читается отдельным дескриптором только для чтения, чтобы не сдвигать позицию записи
+	return readJSONL(l.path, func(line []byte) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var event HistoryEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			return err
+		}
+		return fn(event)
+	})
+}
+
+// Sync Сбрасывает журнал на диск
+func (l *FileHistoryLog) Sync() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.log.sync()
+}
+
+// Close Закрывает файл журнала
+func (l *FileHistoryLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.log.close()
+}
@@ -0,0 +1,188 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	t0 := time.Date(2024, 3, 3, 10, 0, 0, 0, time.UTC)
+
+	h, err := NewHistory(ctx, NewMemoryHistoryLog())
+	require.NoError(t, err)
+
+	driver := DriverProfile{
+		Profile:       &DriverProfileData{Id: "driver-1", FirstName: "Иван", WorkStatus: "working"},
+		Car:           &Vehicle{Id: "car-1", Number: "A001AA77", Color: "Белый"},
+		CurrentStatus: &DriverProfileCurrentStatus{Status: "online"},
+	}
+	events, err := h.ObserveDriverProfile(ctx, t0, driver)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, []HistoryEntity{HistoryDriverProfile, HistoryVehicle, HistoryCarBinding},
+		[]HistoryEntity{events[0].Entity, events[1].Entity, events[2].Entity})
+	require.Empty(t, events[0].Changes)
+
+	// Текущее состояние водителя не входит в историю
+	driver.CurrentStatus = &DriverProfileCurrentStatus{Status: "busy"}
+	events, err = h.ObserveDriverProfile(ctx, t0.Add(time.Minute), driver)
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	events, err = h.ObserveVehicle(ctx, t0.Add(time.Hour), Vehicle{Id: "car-1", Number: "A001AA77", Color: "Черный"})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, []FieldChange{{Field: "color", Old: "Белый", New: "Черный"}}, events[0].Changes)
+
+	driver.Car = &Vehicle{Id: "car-2", Number: "B002BB77"}
+	_, err = h.ObserveDriverProfile(ctx, t0.Add(2*time.Hour), driver)
+	require.NoError(t, err)
+
+	_, err = h.ObserveDriverProfile(ctx, t0.Add(3*time.Hour), DriverProfile{Profile: driver.Profile})
+	require.NoError(t, err)
+
+	_, err = h.ObserveVehicleRemoved(ctx, t0.Add(4*time.Hour), "car-1")
+	require.NoError(t, err)
+
+	t.Run("vehicle as of", func(t *testing.T) {
+		_, found, err := h.VehicleAt("car-1", t0.Add(-time.Second))
+		require.NoError(t, err)
+		require.False(t, found)
+
+		v, found, err := h.VehicleAt("car-1", t0.Add(30*time.Minute))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, "Белый", v.Color)
+
+		v, _, err = h.VehicleAt("car-1", t0.Add(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, "Черный", v.Color)
+
+		_, found, err = h.VehicleAt("car-1", t0.Add(5*time.Hour))
+		require.NoError(t, err)
+		require.False(t, found)
+	})
+
+	t.Run("car binding as of", func(t *testing.T) {
+		carID, found, err := h.CarAt("driver-1", t0.Add(90*time.Minute))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, CarID("car-1"), carID)
+
+		carID, _, err = h.CarAt("driver-1", t0.Add(2*time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, CarID("car-2"), carID)
+
+		_, found, err = h.CarAt("driver-1", t0.Add(3*time.Hour))
+		require.NoError(t, err)
+		require.False(t, found)
+
+		drivers, err := h.DriversAt("car-1", t0)
+		require.NoError(t, err)
+		require.Equal(t, []DriverID{"driver-1"}, drivers)
+
+		drivers, err = h.DriversAt("car-1", t0.Add(2*time.Hour))
+		require.NoError(t, err)
+		require.Empty(t, drivers)
+	})
+
+	t.Run("driver profile as of", func(t *testing.T) {
+		p, found, err := h.DriverProfileAt("driver-1", t0.Add(10*time.Hour))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, "Иван", p.FirstName)
+
+		_, err = h.ObserveDriverRemoved(ctx, t0.Add(10*time.Hour), "driver-1")
+		require.NoError(t, err)
+		_, found, err = h.DriverProfileAt("driver-1", t0.Add(10*time.Hour))
+		require.NoError(t, err)
+		require.False(t, found)
+		require.Len(t, h.Timeline(HistoryDriverProfile, "driver-1"), 2)
+	})
+
+	t.Run("late observation", func(t *testing.T) {
+		// Наблюдение задним числом встает в хронологию по времени
+		_, err := h.ObserveVehicle(ctx, t0.Add(30*time.Minute), Vehicle{Id: "car-1", Number: "A001AA77", Color: "Серый"})
+		require.NoError(t, err)
+
+		v, _, err := h.VehicleAt("car-1", t0.Add(45*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, "Серый", v.Color)
+
+		v, _, err = h.VehicleAt("car-1", t0.Add(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, "Черный", v.Color)
+	})
+}
+
+func TestFileHistoryLog(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	t0 := time.Date(2024, 3, 3, 10, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	log, err := OpenFileHistoryLog(path)
+	require.NoError(t, err)
+	h, err := NewHistory(ctx, log)
+	require.NoError(t, err)
+
+	for i, color := range []string{"Белый", "Черный", "Черный", "Синий"} {
+		_, err = h.ObserveVehicle(ctx, t0.Add(time.Duration(i)*time.Hour), Vehicle{Id: "car-1", Color: color})
+		require.NoError(t, err)
+	}
+	require.NoError(t, log.Sync())
+	require.NoError(t, log.Close())
+	require.ErrorIs(t, log.Append(ctx, HistoryEvent{}), ErrStoreClosed)
+
+	// Оборванная запись после сбоя
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"seq":4,"at":"2024-03-03T`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	log, err = OpenFileHistoryLog(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = log.Close() })
+
+	h, err = NewHistory(ctx, log)
+	require.NoError(t, err)
+	timeline := h.Timeline(HistoryVehicle, "car-1")
+	require.Len(t, timeline, 3)
+	require.Equal(t, []int64{1, 2, 3}, []int64{timeline[0].Seq, timeline[1].Seq, timeline[2].Seq})
+
+	v, found, err := h.VehicleAt("car-1", t0.Add(150*time.Minute))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "Черный", v.Color)
+
+	events, err := h.ObserveVehicle(ctx, t0.Add(5*time.Hour), Vehicle{Id: "car-1", Color: "Красный"})
+	require.NoError(t, err)
+	require.Equal(t, int64(4), events[0].Seq)
+
+	var replayed int
+	require.NoError(t, log.Replay(ctx, func(HistoryEvent) error {
+		replayed++
+		return nil
+	}))
+	require.Equal(t, 4, replayed)
+
+	t.Run("replay is read-only", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing.jsonl")
+		missing := &FileHistoryLog{path: path}
+		require.NoError(t, missing.Replay(ctx, func(HistoryEvent) error {
+			return errors.New("unexpected event")
+		}))
+		_, err := os.Stat(path)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
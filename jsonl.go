@@ -0,0 +1,157 @@
+package yandex_taxi_go
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// jsonlFile Файл формата JSON Lines, в который строки только дописываются. Методы не синхронизированы,
+// вызывающий код защищает файл своей блокировкой
+type jsonlFile struct {
+	path  string
+	file  *os.File
+	size  int64 // Длина файла в байтах
+	lines int   // Число строк в файле
+}
+
+// openJSONL Открывает файл path, создавая его при необходимости, и передает в fn каждую строку.
+// Последняя строка без перевода строки считается оборванной записью и срезается
+func openJSONL(path string, fn func(line []byte) error) (*jsonlFile, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &jsonlFile{path: path, file: file}
+	if err = f.read(fn); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *jsonlFile) read(fn func(line []byte) error) error {
+	r := bufio.NewReader(f.file)
+
+	for n := 1; ; n++ {
+		line, err := r.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if errors.Is(err, io.EOF) {
+			if len(line) > 0 {
+				if err = f.file.Truncate(f.size); err != nil {
+					return err
+				}
+			}
+			break
+		}
+
+		if err = fn(line); err != nil {
+			return fmt.Errorf("%s:%d: %w", f.path, n, err)
+		}
+		f.size += int64(len(line))
+		f.lines++
+	}
+
+	_, err := f.file.Seek(f.size, io.SeekStart)
+	return err
+}
+
+// readJSONL Читает файл path только для чтения и передает в fn каждую строку. Оборванная последняя
+// строка пропускается, файл не изменяется. Отсутствующий файл считается пустым
+func readJSONL(path string, fn func(line []byte) error) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+	for n := 1; ; n++ {
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = fn(line); err != nil {
+			return fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+	}
+}
+
+// append Дописывает v отдельной строкой. Если запись не удалась, частично записанная строка срезается,
+// чтобы следующая запись не склеилась с ней
+func (f *jsonlFile) append(v any) error {
+	if f.file == nil {
+		return ErrStoreClosed
+	}
+
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	if _, err = f.file.Write(line); err != nil {
+		if f.file.Truncate(f.size) == nil {
+			_, _ = f.file.Seek(f.size, io.SeekStart)
+		}
+		return err
+	}
+
+	f.size += int64(len(line))
+	f.lines++
+	return nil
+}
+
+// replace Атомарно заменяет содержимое файла строками data
+func (f *jsonlFile) replace(data []byte, lines int) error {
+	if f.file == nil {
+		return ErrStoreClosed
+	}
+	if err := writeFileAtomic(f.path, data); err != nil {
+		return err
+	}
+
+	// Старый дескриптор указывает на замененный файл
+	file, err := os.OpenFile(f.path, os.O_RDWR, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Seek(int64(len(data)), io.SeekStart); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	_ = f.file.Close()
+	f.file = file
+	f.size = int64(len(data))
+	f.lines = lines
+	return nil
+}
+
+func (f *jsonlFile) sync() error {
+	if f.file == nil {
+		return ErrStoreClosed
+	}
+	return f.file.Sync()
+}
+
+func (f *jsonlFile) close() error {
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
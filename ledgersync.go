@@ -0,0 +1,220 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const defaultLedgerOverlap = 10 * time.Minute
+
+// LedgerSyncConfig Параметры LedgerSync
+type LedgerSyncConfig struct {
+	ParkID      ParkID
+	DriverID    DriverID         // Если задан, синхронизируются транзакции водителя, иначе все транзакции парка
+	CategoryIDs []string         // Фильтр по категориям транзакций. Пустой - все категории
+	Checkpoints CheckpointStore  // Хранилище позиции синхронизации. По умолчанию в памяти
+	Start       time.Time        // Время транзакций, с которого начинается первая синхронизация. По умолчанию время первого запуска минус Overlap
+	Overlap     time.Duration    // Насколько раньше позиции перечитывать транзакции, чтобы не пропустить проведенные задним числом. По умолчанию 10 минут
+	PageLimit   int              // Размер страницы. По умолчанию 1000
+	Interval    time.Duration    // Период опроса в Run. По умолчанию и при отрицательном значении одна минута
+	Now         func() time.Time // Источник текущего времени. По умолчанию time.Now
+}
+
+// LedgerSyncStatus Положение синхронизации относительно API
+type LedgerSyncStatus struct {
+	Position time.Time     // Время самой поздней подтвержденной обработчиком транзакции
+	Newest   time.Time     // Время самой поздней транзакции, полученной из API
+	Lag      time.Duration // Отставание Position от Newest. 0, если все полученные транзакции подтверждены
+}
+
+// LedgerSync Синхронизация журнала транзакций парка или водителя. Проход читает курсором транзакции
+// от позиции минус cfg.Overlap до текущего времени и передает обработчику каждую страницу одним
+// пакетом, без уже подтвержденных транзакций. Курсор и позиция сохраняются только после того,
+// как обработчик подтвердил пакет, вернув nil; при ошибке пакет будет передан снова следующим проходом.
+// Идентификаторы подтвержденных транзакций из окна перекрытия хранятся в позиции синхронизации,
+// поэтому повторы отбрасываются и после перезапуска. Пакет передается повторно, только если процесс
+// остановился между подтверждением и сохранением позиции
+type LedgerSync struct {
+	client *Client
+	cfg    LedgerSyncConfig
+	mu     sync.Mutex
+
+	statusMu sync.Mutex
+	status   LedgerSyncStatus
+}
+
+// NewLedgerSync Создает синхронизацию транзакций парка cfg.ParkID
+func NewLedgerSync(client *Client, cfg LedgerSyncConfig) *LedgerSync {
+	if cfg.Checkpoints == nil {
+		cfg.Checkpoints = NewMemoryCheckpointStore()
+	}
+	if cfg.Overlap == 0 {
+		cfg.Overlap = defaultLedgerOverlap
+	}
+	if cfg.PageLimit == 0 {
+		cfg.PageLimit = defaultPageLimit
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultSyncInterval
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	return &LedgerSync{client: client, cfg: cfg}
+}
+
+func (s *LedgerSync) checkpointKey() string {
+	if s.cfg.DriverID != "" {
+		return "ledger/" + string(s.cfg.ParkID) + "/" + string(s.cfg.DriverID)
+	}
+	return "ledger/" + string(s.cfg.ParkID)
+}
+
+// Status Текущее положение синхронизации. Безопасно вызывать во время Sync
+func (s *LedgerSync) Status() LedgerSyncStatus {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	status := s.status
+	if !status.Newest.IsZero() && status.Newest.After(status.Position) {
+		status.Lag = status.Newest.Sub(status.Position)
+	}
+	return status
+}
+
+// Lag Отставание подтвержденной позиции от самой поздней полученной транзакции
+func (s *LedgerSync) Lag() time.Duration {
+	return s.Status().Lag
+}
+
+func (s *LedgerSync) updateStatus(position time.Time, transactions []Transaction) {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	s.status.Position = position
+	for _, tx := range transactions {
+		if eventAt, err := ParseTime(tx.EventAt); err == nil && eventAt.After(s.status.Newest) {
+			s.status.Newest = eventAt
+		}
+	}
+}
+
+// Sync Выполняет один проход синхронизации и возвращает число подтвержденных транзакций.
+// Ошибка обработчика прерывает проход, курсор остается на неподтвержденном пакете
+func (s *LedgerSync) Sync(ctx context.Context, fn func([]Transaction) error) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.cfg.Now()
+	cp, from, err := startCursorSyncPass(ctx, s.cfg.Checkpoints, s.checkpointKey(), s.cfg.Start, now, s.cfg.Overlap)
+	if err != nil {
+		return 0, err
+	}
+	s.updateStatus(cp.watermark(), nil)
+
+	p := &ledgerSyncPass{sync: s, cp: cp, fn: fn}
+
+	if window, cursor, ok := cp.interrupted(); ok {
+		// Обход окна прервался: окно дочитывается с сохраненного курсора
+		if err = p.window(ctx, window, cursor); err != nil {
+			return p.confirmed, err
+		}
+		from = cp.watermark().Add(-s.cfg.Overlap)
+	}
+
+	if err = p.window(ctx, TimeRange{From: from, To: now}, ""); err != nil {
+		return p.confirmed, err
+	}
+	return p.confirmed, nil
+}
+
+// ledgerSyncPass Состояние одного прохода LedgerSync
+type ledgerSyncPass struct {
+	sync      *LedgerSync
+	cp        *cursorSyncPass
+	fn        func([]Transaction) error
+	confirmed int
+}
+
+// window Читает транзакции окна, начиная с курсора cursor
+func (p *ledgerSyncPass) window(ctx context.Context, window TimeRange, cursor string) error {
+	cfg := p.sync.cfg
+	args := GetTransactionsListArgs{
+		ParkID:      cfg.ParkID,
+		DriverID:    cfg.DriverID,
+		Cursor:      cursor,
+		Limit:       cfg.PageLimit,
+		EventAt:     window,
+		CategoryIDs: cfg.CategoryIDs,
+	}
+
+	for {
+		page, err := p.sync.page(ctx, args)
+		if err != nil {
+			return err
+		}
+		p.sync.updateStatus(p.cp.watermark(), page.Transactions)
+
+		batch := make([]Transaction, 0, len(page.Transactions))
+		eventTimes := make(map[string]time.Time, len(page.Transactions))
+		for _, tx := range page.Transactions {
+			id := string(tx.Id)
+			if p.cp.seen(id) {
+				continue
+			}
+			if _, ok := eventTimes[id]; ok {
+				continue
+			}
+			eventAt, err := ParseTime(tx.EventAt)
+			if err != nil {
+				return fmt.Errorf("transaction %s: event_at: %w", tx.Id, err)
+			}
+			eventTimes[id] = eventAt
+			batch = append(batch, tx)
+		}
+
+		if len(batch) > 0 {
+			if err = p.fn(batch); err != nil {
+				return err
+			}
+			for id, eventAt := range eventTimes {
+				p.cp.mark(id, eventAt)
+				p.cp.advance(eventAt)
+			}
+			p.confirmed += len(batch)
+		}
+
+		next := page.Cursor
+		if len(page.Transactions) == 0 {
+			next = ""
+		}
+		if err = p.cp.commit(ctx, window, next); err != nil {
+			return err
+		}
+		p.sync.updateStatus(p.cp.watermark(), nil)
+
+		if next == "" {
+			return nil
+		}
+		args.Cursor = next
+	}
+}
+
+func (s *LedgerSync) page(ctx context.Context, args GetTransactionsListArgs) (*GetTransactionsListResult, error) {
+	if s.cfg.DriverID != "" {
+		return s.client.GetDriverTransactions(ctx, args)
+	}
+	return s.client.GetParkTransactions(ctx, args)
+}
+
+// Run Запускает Sync по расписанию cfg.Interval и работает до отмены ctx. Ошибки проходов
+// не прерывают работу и записываются в журнал клиента
+func (s *LedgerSync) Run(ctx context.Context, fn func([]Transaction) error) error {
+	return s.client.runEvery(ctx, s.cfg.Interval, "fleet ledger sync failed", s.cfg.ParkID, func(ctx context.Context) error {
+		_, err := s.Sync(ctx, fn)
+		return err
+	})
+}
@@ -0,0 +1,185 @@
+package yandex_taxi_go_test
+
+import (
+	"context"
+	"errors"
+	fleet "github.com/sinland/yandex-taxi-go"
+	"github.com/sinland/yandex-taxi-go/fleettest"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func addTransaction(t *testing.T, s *fleettest.Server, id fleet.TransactionID, driverID fleet.DriverID, eventAt time.Time) {
+	require.NoError(t, s.AddTransaction("park-1", fleet.Transaction{
+		Id:              id,
+		EventAt:         eventAt.Format(time.RFC3339),
+		CategoryId:      "card",
+		Amount:          "100.0000",
+		CurrencyCode:    "RUB",
+		DriverProfileId: driverID,
+	}))
+}
+
+func collectBatches(batches *[][]fleet.TransactionID) func([]fleet.Transaction) error {
+	return func(txs []fleet.Transaction) error {
+		ids := make([]fleet.TransactionID, 0, len(txs))
+		for _, tx := range txs {
+			ids = append(ids, tx.Id)
+		}
+		*batches = append(*batches, ids)
+		return nil
+	}
+}
+
+func TestLedgerSync(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, clock, c := newSyncServer(t)
+	now := clock.Now()
+
+	for i, id := range []fleet.TransactionID{"tx-1", "tx-2", "tx-3", "tx-4", "tx-5"} {
+		driverID := fleet.DriverID("driver-1")
+		if i%2 == 1 {
+			driverID = "driver-2"
+		}
+		addTransaction(t, s, id, driverID, now.Add(time.Duration(i-5)*10*time.Minute))
+	}
+
+	sync := fleet.NewLedgerSync(c, fleet.LedgerSyncConfig{
+		ParkID:    "park-1",
+		Start:     now.Add(-time.Hour),
+		Overlap:   10 * time.Minute,
+		PageLimit: 2,
+		Now:       clock.Now,
+	})
+
+	var batches [][]fleet.TransactionID
+	n, err := sync.Sync(ctx, collectBatches(&batches))
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+	require.Equal(t, [][]fleet.TransactionID{{"tx-1", "tx-2"}, {"tx-3", "tx-4"}, {"tx-5"}}, batches)
+
+	status := sync.Status()
+	require.True(t, now.Add(-10*time.Minute).Equal(status.Position))
+	require.True(t, status.Position.Equal(status.Newest))
+	require.Zero(t, status.Lag)
+
+	t.Run("back-dated inside overlap", func(t *testing.T) {
+		clock.Advance(time.Minute)
+		addTransaction(t, s, "tx-6", "driver-1", now.Add(-15*time.Minute))
+		// Транзакция раньше окна перекрытия уже не будет прочитана
+		addTransaction(t, s, "tx-7", "driver-1", now.Add(-25*time.Minute))
+
+		batches = nil
+		n, err = sync.Sync(ctx, collectBatches(&batches))
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		require.Equal(t, [][]fleet.TransactionID{{"tx-6"}}, batches)
+
+		batches = nil
+		n, err = sync.Sync(ctx, collectBatches(&batches))
+		require.NoError(t, err)
+		require.Zero(t, n)
+		require.Empty(t, batches)
+	})
+
+	t.Run("driver ledger", func(t *testing.T) {
+		checkpoints := fleet.NewMemoryCheckpointStore()
+		driverSync := fleet.NewLedgerSync(c, fleet.LedgerSyncConfig{
+			ParkID:      "park-1",
+			DriverID:    "driver-2",
+			Checkpoints: checkpoints,
+			Start:       now.Add(-time.Hour),
+			Now:         clock.Now,
+		})
+
+		var driverBatches [][]fleet.TransactionID
+		n, err := driverSync.Sync(ctx, collectBatches(&driverBatches))
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.Equal(t, [][]fleet.TransactionID{{"tx-2", "tx-4"}}, driverBatches)
+
+		_, found, err := checkpoints.Load(ctx, "ledger/park-1/driver-2")
+		require.NoError(t, err)
+		require.True(t, found)
+	})
+}
+
+func TestLedgerSync_ConfirmedBatches(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, clock, c := newSyncServer(t)
+	now := clock.Now()
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+
+	all := []fleet.TransactionID{"tx-1", "tx-2", "tx-3", "tx-4", "tx-5"}
+	for i, id := range all {
+		addTransaction(t, s, id, "driver-1", now.Add(time.Duration(i-5)*time.Minute))
+	}
+
+	cfg := fleet.LedgerSyncConfig{
+		ParkID:      "park-1",
+		Checkpoints: fleet.NewFileCheckpointStore(path),
+		Start:       now.Add(-time.Hour),
+		PageLimit:   2,
+		Now:         clock.Now,
+	}
+	sync := fleet.NewLedgerSync(c, cfg)
+
+	var confirmed []fleet.TransactionID
+	errAccounting := errors.New("accounting unavailable")
+	n, err := sync.Sync(ctx, func(txs []fleet.Transaction) error {
+		if txs[0].Id == "tx-3" {
+			return errAccounting
+		}
+		for _, tx := range txs {
+			confirmed = append(confirmed, tx.Id)
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errAccounting)
+	require.Equal(t, 2, n)
+
+	// Позиция остается на последней подтвержденной транзакции, отставание - до самой поздней полученной
+	status := sync.Status()
+	require.True(t, now.Add(-4*time.Minute).Equal(status.Position))
+	require.True(t, now.Add(-2*time.Minute).Equal(status.Newest))
+	require.Equal(t, 2*time.Minute, sync.Lag())
+
+	// Новый процесс с тем же файлом получает неподтвержденный пакет снова и без повторов
+	cfg.Checkpoints = fleet.NewFileCheckpointStore(path)
+	cp, found, err := cfg.Checkpoints.Load(ctx, "ledger/park-1")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.NotEmpty(t, cp.Cursor)
+	require.Len(t, cp.Seen, 2)
+
+	restarted := fleet.NewLedgerSync(c, cfg)
+	n, err = restarted.Sync(ctx, func(txs []fleet.Transaction) error {
+		for _, tx := range txs {
+			confirmed = append(confirmed, tx.Id)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, all, confirmed)
+	require.Zero(t, restarted.Lag())
+}
+
+func TestLedgerSync_RunNegativeInterval(t *testing.T) {
+	t.Parallel()
+
+	s, clock, c := newSyncServer(t)
+	addTransaction(t, s, "tx-1", "driver-1", clock.Now().Add(-time.Minute))
+
+	sync := fleet.NewLedgerSync(c, fleet.LedgerSyncConfig{ParkID: "park-1", Interval: -time.Second, Now: clock.Now})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, sync.Run(ctx, func([]fleet.Transaction) error { return nil }), context.Canceled)
+}
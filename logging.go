@@ -0,0 +1,96 @@
+package yandex_taxi_go
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// maxLoggedErrorBody Ограничение на размер тела ответа с ошибкой, которое попадает в лог
+const maxLoggedErrorBody = 4096
+
+// WithLogger Логгер клиента. По умолчанию используется slog.Default()
+func WithLogger(logger *slog.Logger) func(client *Client) {
+	return func(s *Client) {
+		s.logger = logger
+	}
+}
+
+// WithRedactionPolicy Правила маскирования данных в логах. По умолчанию DefaultRedactionPolicy()
+func WithRedactionPolicy(policy RedactionPolicy) func(client *Client) {
+	return func(s *Client) {
+		s.redaction = policy
+	}
+}
+
+func (c *Client) getLogger() *slog.Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+	return slog.Default()
+}
+
+// loggingMiddleware Пишет в лог сводку по каждому запросу и ответу с учетом правил маскирования
+func (c *Client) loggingMiddleware(next RoundTrip) RoundTrip {
+	return func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+		logger := c.getLogger()
+		info, _ := CallInfoFromContext(ctx)
+
+		if logger.Enabled(ctx, slog.LevelDebug) {
+			attrs := []any{
+				"endpoint", info.Endpoint,
+				"method", req.Method,
+				"url", req.URL.String(),
+				"headers", c.redaction.RedactHeaders(req.Header),
+			}
+			if req.GetBody != nil {
+				if body, err := req.GetBody(); err == nil {
+					data, _ := io.ReadAll(body)
+					_ = body.Close()
+					attrs = append(attrs, "body", string(c.redaction.RedactJSON(data)))
+				}
+			}
+			logger.DebugContext(ctx, "fleet api request", attrs...)
+		}
+
+		start := time.Now()
+		res, err := next(req)
+		duration := time.Since(start)
+
+		if err != nil {
+			logger.WarnContext(ctx, "fleet api request failed",
+				"endpoint", info.Endpoint,
+				"duration", duration,
+				"error", err,
+			)
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusOK {
+			data, _ := io.ReadAll(io.LimitReader(res.Body, maxLoggedErrorBody))
+			res.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(data), res.Body), res.Body}
+
+			logger.WarnContext(ctx, "fleet api error response",
+				"endpoint", info.Endpoint,
+				"status_code", res.StatusCode,
+				"duration", duration,
+				"body", string(c.redaction.RedactJSON(data)),
+			)
+			return res, nil
+		}
+
+		logger.DebugContext(ctx, "fleet api response",
+			"endpoint", info.Endpoint,
+			"status_code", res.StatusCode,
+			"content_length", res.ContentLength,
+			"duration", duration,
+		)
+		return res, nil
+	}
+}
@@ -0,0 +1,133 @@
+package yandex_taxi_go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestClient_Logging(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+	)
+
+	t.Run("request and response summary", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bytes, _ := json.Marshal(GetDriverProfilesResult{})
+			_, err := w.Write(bytes)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		out := &syncBuffer{}
+		logger := slog.New(slog.NewJSONHandler(out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		c := NewClient(ClientConfig{
+			ClientID: testClientID,
+			APIKey:   testAPIKey,
+		}, WithAPIHost(server.URL), WithLogger(logger), WithRedactionPolicy(RedactionPolicy{
+			Headers: []string{headerXAPIKey},
+			Fields:  []string{"query.text"},
+		}))
+
+		_, err := c.GetDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id", QueryText: "+79999999999"})
+		require.NoError(t, err)
+
+		logs := out.String()
+		require.NotContains(t, logs, testAPIKey)
+		require.NotContains(t, logs, "+79999999999")
+		require.Contains(t, logs, `"msg":"fleet api request"`)
+		require.Contains(t, logs, `"msg":"fleet api response"`)
+		require.Contains(t, logs, EndpointDriverProfilesList)
+		require.Contains(t, logs, "park-id")
+	})
+
+	t.Run("default policy", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Прокси отдал тело ответа списка заказов со статусом ошибки, тело попадает в лог
+			w.WriteHeader(http.StatusBadGateway)
+			_, err := w.Write([]byte(`{"cursor":"","limit":500,"orders":[{"id":"order-id","status":"complete","booked_at":"2024-03-03T10:00:00+0300","driver_profile":{"id":"driver-id","name":"Petrov Petr Petrovich"},"car":{"id":"car-id","brand_model":"Kia Rio","callsign":"101","license":{"number":"Т8654Т99"}}}]}`))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		out := &syncBuffer{}
+		logger := slog.New(slog.NewJSONHandler(out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		c := NewClient(ClientConfig{
+			ClientID: testClientID,
+			APIKey:   testAPIKey,
+		}, WithAPIHost(server.URL), WithLogger(logger))
+
+		_, err := c.GetOrdersList(ctx, GetOrdersListArgs{ParkID: "park-id"})
+		require.Error(t, err)
+		_, err = c.GetDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id", QueryText: "+79999999999"})
+		require.Error(t, err)
+
+		logs := out.String()
+		require.Contains(t, logs, `"msg":"fleet api error response"`)
+		require.Contains(t, logs, "Kia Rio")
+		require.NotContains(t, logs, "Petrov")
+		require.NotContains(t, logs, "+79999999999")
+		require.NotContains(t, logs, testAPIKey)
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, err := w.Write([]byte(`{"code":"400","message":"invalid phone","phone":"+79999999999"}`))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		out := &syncBuffer{}
+		logger := slog.New(slog.NewJSONHandler(out, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+		c := NewClient(ClientConfig{
+			ClientID: testClientID,
+			APIKey:   testAPIKey,
+		}, WithAPIHost(server.URL), WithLogger(logger))
+
+		_, err := c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-id"})
+		require.Error(t, err)
+		require.Equal(t, "[400] invalid phone (400)", err.Error())
+
+		logs := out.String()
+		require.Equal(t, 1, strings.Count(logs, "\n"))
+		require.Contains(t, logs, `"msg":"fleet api error response"`)
+		require.Contains(t, logs, `"status_code":400`)
+		require.NotContains(t, logs, "+79999999999")
+	})
+}
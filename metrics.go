@@ -0,0 +1,66 @@
+package yandex_taxi_go
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// StatusClassError Класс статуса для запросов, завершившихся без ответа сервера
+const StatusClassError = "error"
+
+// Metrics Приемник метрик клиента. Методы вызываются конкурентно и не должны блокироваться
+type Metrics interface {
+	// RequestStarted Начало HTTP-запроса к API (включая повторные попытки)
+	RequestStarted(endpoint string)
+	// RequestFinished Завершение HTTP-запроса с указанием класса статуса ("2xx", "4xx", "5xx", "error")
+	RequestFinished(endpoint, statusClass string, duration time.Duration)
+	// Retry Повторная попытка запроса после ответа с указанным классом статуса
+	Retry(endpoint, statusClass string)
+	// RateLimitWait Время ожидания ограничителя частоты запросов перед отправкой
+	RateLimitWait(endpoint string, wait time.Duration)
+}
+
+// WithMetrics Приемник метрик клиента
+func WithMetrics(metrics Metrics) func(client *Client) {
+	return func(s *Client) {
+		if metrics == nil {
+			metrics = nopMetrics{}
+		}
+		s.metrics = metrics
+	}
+}
+
+// StatusClass Класс HTTP-статуса ответа, например "2xx" для 200
+func StatusClass(statusCode int) string {
+	if statusCode < 100 || statusCode > 599 {
+		return StatusClassError
+	}
+	return strconv.Itoa(statusCode/100) + "xx"
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) RequestStarted(string)                         {}
+func (nopMetrics) RequestFinished(string, string, time.Duration) {}
+func (nopMetrics) Retry(string, string)                          {}
+func (nopMetrics) RateLimitWait(string, time.Duration)           {}
+
+// metricsMiddleware Учитывает каждую попытку запроса: количество, длительность и число запросов в работе
+func (c *Client) metricsMiddleware(next RoundTrip) RoundTrip {
+	return func(req *http.Request) (*http.Response, error) {
+		info, _ := CallInfoFromContext(req.Context())
+
+		c.metrics.RequestStarted(info.Endpoint)
+		start := time.Now()
+
+		res, err := next(req)
+		if err != nil {
+			c.metrics.RequestFinished(info.Endpoint, StatusClassError, time.Since(start))
+			return nil, err
+		}
+
+		c.metrics.RequestFinished(info.Endpoint, StatusClass(res.StatusCode), time.Since(start))
+		return res, nil
+	}
+}
@@ -0,0 +1,400 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sinland/yandex-taxi-go (interfaces: FleetAPI,ParkAPI)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/fleet_api.go -package=mocks . FleetAPI,ParkAPI
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	yandex_taxi_go "github.com/sinland/yandex-taxi-go"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFleetAPI is a mock of FleetAPI interface.
+type MockFleetAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockFleetAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockFleetAPIMockRecorder is the mock recorder for MockFleetAPI.
+type MockFleetAPIMockRecorder struct {
+	mock *MockFleetAPI
+}
+
+// NewMockFleetAPI creates a new mock instance.
+func NewMockFleetAPI(ctrl *gomock.Controller) *MockFleetAPI {
+	mock := &MockFleetAPI{ctrl: ctrl}
+	mock.recorder = &MockFleetAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFleetAPI) EXPECT() *MockFleetAPIMockRecorder {
+	return m.recorder
+}
+
+// GetCarsList mocks base method.
+func (m *MockFleetAPI) GetCarsList(ctx context.Context, args yandex_taxi_go.GetCarsListArgs, opts ...yandex_taxi_go.CallOption) (*yandex_taxi_go.GetCarsListResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, args}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCarsList", varargs...)
+	ret0, _ := ret[0].(*yandex_taxi_go.GetCarsListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarsList indicates an expected call of GetCarsList.
+func (mr *MockFleetAPIMockRecorder) GetCarsList(ctx, args any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, args}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarsList", reflect.TypeOf((*MockFleetAPI)(nil).GetCarsList), varargs...)
+}
+
+// GetDriverProfiles mocks base method.
+func (m *MockFleetAPI) GetDriverProfiles(ctx context.Context, args yandex_taxi_go.GetDriverProfilesArgs, opts ...yandex_taxi_go.CallOption) (*yandex_taxi_go.GetDriverProfilesResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, args}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDriverProfiles", varargs...)
+	ret0, _ := ret[0].(*yandex_taxi_go.GetDriverProfilesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDriverProfiles indicates an expected call of GetDriverProfiles.
+func (mr *MockFleetAPIMockRecorder) GetDriverProfiles(ctx, args any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, args}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDriverProfiles", reflect.TypeOf((*MockFleetAPI)(nil).GetDriverProfiles), varargs...)
+}
+
+// GetDriverTransactions mocks base method.
+func (m *MockFleetAPI) GetDriverTransactions(ctx context.Context, args yandex_taxi_go.GetTransactionsListArgs, opts ...yandex_taxi_go.CallOption) (*yandex_taxi_go.GetTransactionsListResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, args}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDriverTransactions", varargs...)
+	ret0, _ := ret[0].(*yandex_taxi_go.GetTransactionsListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDriverTransactions indicates an expected call of GetDriverTransactions.
+func (mr *MockFleetAPIMockRecorder) GetDriverTransactions(ctx, args any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, args}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDriverTransactions", reflect.TypeOf((*MockFleetAPI)(nil).GetDriverTransactions), varargs...)
+}
+
+// GetOrdersList mocks base method.
+func (m *MockFleetAPI) GetOrdersList(ctx context.Context, args yandex_taxi_go.GetOrdersListArgs, opts ...yandex_taxi_go.CallOption) (*yandex_taxi_go.GetOrdersListResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, args}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetOrdersList", varargs...)
+	ret0, _ := ret[0].(*yandex_taxi_go.GetOrdersListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersList indicates an expected call of GetOrdersList.
+func (mr *MockFleetAPIMockRecorder) GetOrdersList(ctx, args any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, args}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersList", reflect.TypeOf((*MockFleetAPI)(nil).GetOrdersList), varargs...)
+}
+
+// GetParkTransactions mocks base method.
+func (m *MockFleetAPI) GetParkTransactions(ctx context.Context, args yandex_taxi_go.GetTransactionsListArgs, opts ...yandex_taxi_go.CallOption) (*yandex_taxi_go.GetTransactionsListResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, args}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetParkTransactions", varargs...)
+	ret0, _ := ret[0].(*yandex_taxi_go.GetTransactionsListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParkTransactions indicates an expected call of GetParkTransactions.
+func (mr *MockFleetAPIMockRecorder) GetParkTransactions(ctx, args any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, args}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParkTransactions", reflect.TypeOf((*MockFleetAPI)(nil).GetParkTransactions), varargs...)
+}
+
+// ScanDriverProfiles mocks base method.
+func (m *MockFleetAPI) ScanDriverProfiles(ctx context.Context, args yandex_taxi_go.GetDriverProfilesArgs, concurrency int, opts ...yandex_taxi_go.CallOption) (*yandex_taxi_go.GetDriverProfilesResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, args, concurrency}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ScanDriverProfiles", varargs...)
+	ret0, _ := ret[0].(*yandex_taxi_go.GetDriverProfilesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScanDriverProfiles indicates an expected call of ScanDriverProfiles.
+func (mr *MockFleetAPIMockRecorder) ScanDriverProfiles(ctx, args, concurrency any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, args, concurrency}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanDriverProfiles", reflect.TypeOf((*MockFleetAPI)(nil).ScanDriverProfiles), varargs...)
+}
+
+// SnapshotDriverProfiles mocks base method.
+func (m *MockFleetAPI) SnapshotDriverProfiles(ctx context.Context, args yandex_taxi_go.GetDriverProfilesArgs, snap yandex_taxi_go.SnapshotOptions, opts ...yandex_taxi_go.CallOption) (*yandex_taxi_go.DriverProfilesSnapshot, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, args, snap}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SnapshotDriverProfiles", varargs...)
+	ret0, _ := ret[0].(*yandex_taxi_go.DriverProfilesSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnapshotDriverProfiles indicates an expected call of SnapshotDriverProfiles.
+func (mr *MockFleetAPIMockRecorder) SnapshotDriverProfiles(ctx, args, snap any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, args, snap}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotDriverProfiles", reflect.TypeOf((*MockFleetAPI)(nil).SnapshotDriverProfiles), varargs...)
+}
+
+// StreamDriverProfiles mocks base method.
+func (m *MockFleetAPI) StreamDriverProfiles(ctx context.Context, args yandex_taxi_go.GetDriverProfilesArgs, fn func(yandex_taxi_go.DriverProfile) error, opts ...yandex_taxi_go.CallOption) (*yandex_taxi_go.DriverProfilesPage, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, args, fn}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StreamDriverProfiles", varargs...)
+	ret0, _ := ret[0].(*yandex_taxi_go.DriverProfilesPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamDriverProfiles indicates an expected call of StreamDriverProfiles.
+func (mr *MockFleetAPIMockRecorder) StreamDriverProfiles(ctx, args, fn any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, args, fn}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamDriverProfiles", reflect.TypeOf((*MockFleetAPI)(nil).StreamDriverProfiles), varargs...)
+}
+
+// MockParkAPI is a mock of ParkAPI interface.
+type MockParkAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockParkAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockParkAPIMockRecorder is the mock recorder for MockParkAPI.
+type MockParkAPIMockRecorder struct {
+	mock *MockParkAPI
+}
+
+// NewMockParkAPI creates a new mock instance.
+func NewMockParkAPI(ctrl *gomock.Controller) *MockParkAPI {
+	mock := &MockParkAPI{ctrl: ctrl}
+	mock.recorder = &MockParkAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockParkAPI) EXPECT() *MockParkAPIMockRecorder {
+	return m.recorder
+}
+
+// GetCarsList mocks base method.
+func (m *MockParkAPI) GetCarsList(ctx context.Context, args yandex_taxi_go.ParkCarsListArgs, opts ...yandex_taxi_go.CallOption) (*yandex_taxi_go.GetCarsListResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, args}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCarsList", varargs...)
+	ret0, _ := ret[0].(*yandex_taxi_go.GetCarsListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarsList indicates an expected call of GetCarsList.
+func (mr *MockParkAPIMockRecorder) GetCarsList(ctx, args any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, args}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarsList", reflect.TypeOf((*MockParkAPI)(nil).GetCarsList), varargs...)
+}
+
+// GetDriverProfiles mocks base method.
+func (m *MockParkAPI) GetDriverProfiles(ctx context.Context, args yandex_taxi_go.ParkDriverProfilesArgs, opts ...yandex_taxi_go.CallOption) (*yandex_taxi_go.GetDriverProfilesResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, args}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDriverProfiles", varargs...)
+	ret0, _ := ret[0].(*yandex_taxi_go.GetDriverProfilesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDriverProfiles indicates an expected call of GetDriverProfiles.
+func (mr *MockParkAPIMockRecorder) GetDriverProfiles(ctx, args any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, args}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDriverProfiles", reflect.TypeOf((*MockParkAPI)(nil).GetDriverProfiles), varargs...)
+}
+
+// GetDriverTransactions mocks base method.
+func (m *MockParkAPI) GetDriverTransactions(ctx context.Context, driverID yandex_taxi_go.DriverID, args yandex_taxi_go.ParkTransactionsArgs, opts ...yandex_taxi_go.CallOption) (*yandex_taxi_go.GetTransactionsListResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, driverID, args}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDriverTransactions", varargs...)
+	ret0, _ := ret[0].(*yandex_taxi_go.GetTransactionsListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDriverTransactions indicates an expected call of GetDriverTransactions.
+func (mr *MockParkAPIMockRecorder) GetDriverTransactions(ctx, driverID, args any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, driverID, args}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDriverTransactions", reflect.TypeOf((*MockParkAPI)(nil).GetDriverTransactions), varargs...)
+}
+
+// GetOrdersList mocks base method.
+func (m *MockParkAPI) GetOrdersList(ctx context.Context, args yandex_taxi_go.ParkOrdersListArgs, opts ...yandex_taxi_go.CallOption) (*yandex_taxi_go.GetOrdersListResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, args}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetOrdersList", varargs...)
+	ret0, _ := ret[0].(*yandex_taxi_go.GetOrdersListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersList indicates an expected call of GetOrdersList.
+func (mr *MockParkAPIMockRecorder) GetOrdersList(ctx, args any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, args}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersList", reflect.TypeOf((*MockParkAPI)(nil).GetOrdersList), varargs...)
+}
+
+// GetTransactions mocks base method.
+func (m *MockParkAPI) GetTransactions(ctx context.Context, args yandex_taxi_go.ParkTransactionsArgs, opts ...yandex_taxi_go.CallOption) (*yandex_taxi_go.GetTransactionsListResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, args}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTransactions", varargs...)
+	ret0, _ := ret[0].(*yandex_taxi_go.GetTransactionsListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactions indicates an expected call of GetTransactions.
+func (mr *MockParkAPIMockRecorder) GetTransactions(ctx, args any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, args}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactions", reflect.TypeOf((*MockParkAPI)(nil).GetTransactions), varargs...)
+}
+
+// ID mocks base method.
+func (m *MockParkAPI) ID() yandex_taxi_go.ParkID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(yandex_taxi_go.ParkID)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockParkAPIMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockParkAPI)(nil).ID))
+}
+
+// ScanDriverProfiles mocks base method.
+func (m *MockParkAPI) ScanDriverProfiles(ctx context.Context, args yandex_taxi_go.ParkDriverProfilesArgs, concurrency int, opts ...yandex_taxi_go.CallOption) (*yandex_taxi_go.GetDriverProfilesResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, args, concurrency}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ScanDriverProfiles", varargs...)
+	ret0, _ := ret[0].(*yandex_taxi_go.GetDriverProfilesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScanDriverProfiles indicates an expected call of ScanDriverProfiles.
+func (mr *MockParkAPIMockRecorder) ScanDriverProfiles(ctx, args, concurrency any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, args, concurrency}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanDriverProfiles", reflect.TypeOf((*MockParkAPI)(nil).ScanDriverProfiles), varargs...)
+}
+
+// SnapshotDriverProfiles mocks base method.
+func (m *MockParkAPI) SnapshotDriverProfiles(ctx context.Context, args yandex_taxi_go.ParkDriverProfilesArgs, snap yandex_taxi_go.SnapshotOptions, opts ...yandex_taxi_go.CallOption) (*yandex_taxi_go.DriverProfilesSnapshot, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, args, snap}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SnapshotDriverProfiles", varargs...)
+	ret0, _ := ret[0].(*yandex_taxi_go.DriverProfilesSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnapshotDriverProfiles indicates an expected call of SnapshotDriverProfiles.
+func (mr *MockParkAPIMockRecorder) SnapshotDriverProfiles(ctx, args, snap any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, args, snap}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotDriverProfiles", reflect.TypeOf((*MockParkAPI)(nil).SnapshotDriverProfiles), varargs...)
+}
+
+// StreamDriverProfiles mocks base method.
+func (m *MockParkAPI) StreamDriverProfiles(ctx context.Context, args yandex_taxi_go.ParkDriverProfilesArgs, fn func(yandex_taxi_go.DriverProfile) error, opts ...yandex_taxi_go.CallOption) (*yandex_taxi_go.DriverProfilesPage, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, args, fn}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StreamDriverProfiles", varargs...)
+	ret0, _ := ret[0].(*yandex_taxi_go.DriverProfilesPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamDriverProfiles indicates an expected call of StreamDriverProfiles.
+func (mr *MockParkAPIMockRecorder) StreamDriverProfiles(ctx, args, fn any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, args, fn}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamDriverProfiles", reflect.TypeOf((*MockParkAPI)(nil).StreamDriverProfiles), varargs...)
+}
@@ -1,97 +1,242 @@
 package yandex_taxi_go
 
+import "time"
+
+// ParkID Идентификатор партнёра (парка)
+type ParkID string
+
+// DriverID Идентификатор профиля водителя
+type DriverID string
+
+// CarID Идентификатор ТС
+type CarID string
+
+// OrderID Идентификатор заказа
+type OrderID string
+
+// TransactionID Идентификатор транзакции
+type TransactionID string
+
 // Vehicle Данные ТС
 type Vehicle struct {
-	Id               string   // Идентификатор ТС
-	Amenities        []string // Удобства в ТС
-	Brand            string   // Марка ТС
-	Callsign         string   // Позывной
-	Category         []string // Список категорий ТС
-	Color            string   // Цвет ТС
-	Model            string   // Модель ТС
-	Number           string   // Государственный регистрационный номер
-	RegistrationCert string   // Номер свидетельства о регистрации ТС (Обязательное поле для России)
-	Status           string   // Статус ТС
-	Vin              string   // VIN (Обязательное поле для России)
-	Year             int      // Год выпуска ТС
+	Id               CarID    `json:"id"`                // Идентификатор ТС
+	Amenities        []string `json:"amenities"`         // Удобства в ТС
+	Brand            string   `json:"brand"`             // Марка ТС
+	Callsign         string   `json:"callsign"`          // Позывной
+	Category         []string `json:"category"`          // Список категорий ТС
+	Color            string   `json:"color"`             // Цвет ТС
+	Model            string   `json:"model"`             // Модель ТС
+	Number           string   `json:"number"`            // Государственный регистрационный номер
+	RegistrationCert string   `json:"registration_cert"` // Номер свидетельства о регистрации ТС (Обязательное поле для России)
+	Status           string   `json:"status"`            // Статус ТС
+	Vin              string   `json:"vin"`               // VIN (Обязательное поле для России)
+	Year             int      `json:"year"`              // Год выпуска ТС
 }
 
 type DriverProfileAccount struct {
-	Id           string // Идентификатор счета
-	Balance      string // Текущий баланс (сумма с фиксированной точностью)
-	BalanceLimit string // Лимит по счету
-	Currency     string // Валюта в формате ISO 4217
-	Type         string // Тип счета
+	Id           string `json:"id"`            // Идентификатор счета
+	Balance      string `json:"balance"`       // Текущий баланс (сумма с фиксированной точностью)
+	BalanceLimit string `json:"balance_limit"` // Лимит по счету
+	Currency     string `json:"currency"`      // Валюта в формате ISO 4217
+	Type         string `json:"type"`          // Тип счета
 }
 
 type DriverProfileCurrentStatus struct {
-	Status          string // Текущее состояние водителя
-	StatusUpdatedAt string // Время последнего обновления текущего состояния водителя в формате ISO 8601.
+	Status          string `json:"status"`            // Текущее состояние водителя
+	StatusUpdatedAt string `json:"status_updated_at"` // Время последнего обновления текущего состояния водителя в формате ISO 8601.
 }
 
 type DriverLicense struct {
-	IssueDate        string
-	ExpirationDate   string
-	Number           string
-	NormalizedNumber string
-	Country          string
-	BirthDate        string
+	IssueDate        string `json:"issue_date"`
+	ExpirationDate   string `json:"expiration_date"`
+	Number           string `json:"number"`
+	NormalizedNumber string `json:"normalized_number"`
+	Country          string `json:"country"`
+	BirthDate        string `json:"birth_date"`
 }
 
 type DriverProfileData struct {
-	Id               string        // Идентификатор профиля водителя
-	CheckMessage     string        // Прочее (доступно сотрудникам парка)
-	Comment          string        // ...
-	CreatedDate      string        // Дата создания профиля в формате ISO 8601
-	DriverLicense    DriverLicense // Водительское удостоверение
-	EmploymentType   string        // Тип занятости водителя
-	FirstName        string        // Имя
-	HasContractIssue bool          // Существуют проблемы с подтверждением занятости
-	LastName         string        // Фамилия
-	MiddleName       string        // Отчество
-	ParkId           string        // Идентификатор партнёра
-	Phones           []string      // Номер телефона
-	WorkRuleId       string        // Идентификатор условия работы
-	WorkStatus       string        // Статус работы водителя
+	Id               DriverID      `json:"id"`                 // Идентификатор профиля водителя
+	CheckMessage     string        `json:"check_message"`      // Прочее (доступно сотрудникам парка)
+	Comment          string        `json:"comment"`            // ...
+	CreatedDate      string        `json:"created_date"`       // Дата создания профиля в формате ISO 8601
+	DriverLicense    DriverLicense `json:"driver_license"`     // Водительское удостоверение
+	EmploymentType   string        `json:"employment_type"`    // Тип занятости водителя
+	FirstName        string        `json:"first_name"`         // Имя
+	HasContractIssue bool          `json:"has_contract_issue"` // Существуют проблемы с подтверждением занятости
+	LastName         string        `json:"last_name"`          // Фамилия
+	MiddleName       string        `json:"middle_name"`        // Отчество
+	ParkId           ParkID        `json:"park_id"`            // Идентификатор партнёра
+	Phones           []string      `json:"phones"`             // Номер телефона
+	WorkRuleId       string        `json:"work_rule_id"`       // Идентификатор условия работы
+	WorkStatus       string        `json:"work_status"`        // Статус работы водителя
 }
 
 type DriverProfilePark struct {
-	Id   string // Идентификатор партнёра
-	City string // Город партнера
-	Name string // Название партнера
+	Id   ParkID `json:"id"`   // Идентификатор партнёра
+	City string `json:"city"` // Город партнера
+	Name string `json:"name"` // Название партнера
 }
 
 type DriverProfile struct {
-	Accounts      []DriverProfileAccount      //Список счетов, которые связаны с водителем.
-	Car           *Vehicle                    // Данные ТС
-	CurrentStatus *DriverProfileCurrentStatus // ..
-	Profile       *DriverProfileData          // Профиль водителя
+	Accounts      []DriverProfileAccount      `json:"accounts"`             // Список счетов, которые связаны с водителем.
+	Car           *Vehicle                    `json:"car"`                  // Данные ТС
+	CurrentStatus *DriverProfileCurrentStatus `json:"current_status"`       // ..
+	Profile       *DriverProfileData          `json:"driver_profile"`       // Профиль водителя
+	UpdatedAt     string                      `json:"updated_at,omitempty"` // Время последнего обновления профиля в формате ISO 8601. Возвращается при фильтре по UpdatedAt
 }
 
 type GetCarsListArgs struct {
-	ParkID string
+	ParkID ParkID
 	Page   int
 	Limit  int
 }
 
 type GetCarsListResult struct {
-	Total  int       // Общее число автомобилей, удовлетворяющих запросу
-	Offset int       // Отступ, начиная с которого возвращаются автомобили в ответе
-	Limit  int       // Ограничение сверху на число автомобилей в ответе
-	Cars   []Vehicle // Данные ТС
+	Total  int       `json:"total"`  // Общее число автомобилей, удовлетворяющих запросу
+	Offset int       `json:"offset"` // Отступ, начиная с которого возвращаются автомобили в ответе
+	Limit  int       `json:"limit"`  // Ограничение сверху на число автомобилей в ответе
+	Cars   []Vehicle `json:"cars"`   // Данные ТС
+
+	Meta ResponseMeta `json:"-"` // Сведения о происхождении ответа
+}
+
+// TimeRange Полуинтервал времени [From, To). Нулевое значение границы - без ограничения
+type TimeRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// DriverProfileFields Поля профиля, которые необходимо извлечь, по блокам ответа.
+// nil - все поля блока, пустой срез - исключить блок
+type DriverProfileFields struct {
+	Account       []string
+	Car           []string
+	CurrentStatus []string
+	DriverProfile []string
+	Park          []string
+}
+
+// DriverProfileSortOrder Поле, по которому упорядочивается список профилей
+type DriverProfileSortOrder struct {
+	Field     string // Поле в формате API, например "driver_profile.created_date"
+	Direction string // Направление сортировки: "asc" или "desc"
 }
 
 type GetDriverProfilesArgs struct {
 	Offset    int
 	Limit     int
 	QueryText string
-	ParkId    string
+	ParkID    ParkID
+	UpdatedAt *TimeRange               // Фильтр по времени последнего обновления профиля
+	Fields    *DriverProfileFields     // Поля профиля, которые необходимо извлечь. nil - все поля
+	SortOrder []DriverProfileSortOrder // Порядок профилей в ответе. Пустой - порядок по умолчанию
 }
 
 type GetDriverProfilesResult struct {
-	Total          int                 // Общее число автомобилей, удовлетворяющих запросу
-	Offset         int                 // Отступ, начиная с которого возвращаются автомобили в ответе
-	Limit          int                 // Ограничение сверху на число автомобилей в ответе
-	DriverProfiles []DriverProfile     // Список профилей
-	Parks          []DriverProfilePark // Список партнеров
+	Total          int                 `json:"total"`           // Общее число автомобилей, удовлетворяющих запросу
+	Offset         int                 `json:"offset"`          // Отступ, начиная с которого возвращаются автомобили в ответе
+	Limit          int                 `json:"limit"`           // Ограничение сверху на число автомобилей в ответе
+	DriverProfiles []DriverProfile     `json:"driver_profiles"` // Список профилей
+	Parks          []DriverProfilePark `json:"parks"`           // Список партнеров
+
+	Meta ResponseMeta `json:"-"` // Сведения о происхождении ответа
+}
+
+type OrderDriverProfile struct {
+	Id   DriverID `json:"id"`   // Идентификатор профиля водителя
+	Name string   `json:"name"` // ФИО водителя
+}
+
+type OrderCarLicense struct {
+	Number string `json:"number"` // Государственный регистрационный номер
+}
+
+type OrderCar struct {
+	Id         CarID           `json:"id"`          // Идентификатор ТС
+	BrandModel string          `json:"brand_model"` // Марка и модель ТС
+	Callsign   string          `json:"callsign"`    // Позывной
+	License    OrderCarLicense `json:"license"`     // Данные государственного регистрационного номера
+}
+
+type OrderAddress struct {
+	Address string  `json:"address"` // Адрес
+	Lat     float64 `json:"lat"`     // Широта
+	Lon     float64 `json:"lon"`     // Долгота
+}
+
+type OrderEvent struct {
+	EventAt     string `json:"event_at"`     // Время события в формате ISO 8601
+	OrderStatus string `json:"order_status"` // Статус заказа после события
+}
+
+// Order Заказ
+type Order struct {
+	Id            OrderID             `json:"id"`                 // Идентификатор заказа
+	ShortId       int                 `json:"short_id"`           // Короткий номер заказа
+	Status        string              `json:"status"`             // Статус заказа (complete, cancelled, driving, ...)
+	CreatedAt     string              `json:"created_at"`         // Время создания заказа в формате ISO 8601
+	BookedAt      string              `json:"booked_at"`          // Время бронирования заказа в формате ISO 8601
+	EndedAt       string              `json:"ended_at,omitempty"` // Время завершения заказа в формате ISO 8601
+	Provider      string              `json:"provider"`           // Источник заказа (platform, partner)
+	Category      string              `json:"category"`           // Тариф
+	PaymentMethod string              `json:"payment_method"`     // Способ оплаты (cash, cashless, ...)
+	Price         string              `json:"price"`              // Стоимость заказа (сумма с фиксированной точностью)
+	Mileage       string              `json:"mileage,omitempty"`  // Пробег в метрах
+	DriverProfile *OrderDriverProfile `json:"driver_profile"`     // Водитель
+	Car           *OrderCar           `json:"car"`                // ТС
+	AddressFrom   *OrderAddress       `json:"address_from"`       // Адрес подачи
+	RoutePoints   []OrderAddress      `json:"route_points"`       // Точки маршрута
+	Events        []OrderEvent        `json:"events,omitempty"`   // История смены статусов
+}
+
+type GetOrdersListArgs struct {
+	ParkID   ParkID
+	Cursor   string    // Курсор следующей страницы из GetOrdersListResult.Cursor. Пустая строка - первая страница
+	Limit    int       // Размер страницы. По умолчанию 500
+	BookedAt TimeRange // Полуинтервал времени бронирования заказа. Обе границы обязательны
+	Statuses []string  // Фильтр по статусу заказа. Пустой - все статусы
+}
+
+type GetOrdersListResult struct {
+	Cursor string  `json:"cursor"` // Курсор следующей страницы. Пустая строка - страниц больше нет
+	Limit  int     `json:"limit"`  // Ограничение сверху на число заказов в ответе
+	Orders []Order `json:"orders"` // Список заказов
+
+	Meta ResponseMeta `json:"-"` // Сведения о происхождении ответа
+}
+
+type TransactionCreatedBy struct {
+	Identity string `json:"identity"` // Инициатор транзакции (platform, dispatcher, fleet-api, ...)
+}
+
+// Transaction Транзакция по счету водителя или парка
+type Transaction struct {
+	Id              TransactionID        `json:"id"`                          // Идентификатор транзакции
+	EventAt         string               `json:"event_at"`                    // Время транзакции в формате ISO 8601
+	CategoryId      string               `json:"category_id"`                 // Идентификатор категории транзакции
+	CategoryName    string               `json:"category_name"`               // Название категории транзакции
+	GroupId         string               `json:"group_id"`                    // Идентификатор группы категорий
+	Amount          string               `json:"amount"`                      // Сумма транзакции (сумма с фиксированной точностью)
+	CurrencyCode    string               `json:"currency_code"`               // Валюта в формате ISO 4217
+	Description     string               `json:"description"`                 // Описание
+	CreatedBy       TransactionCreatedBy `json:"created_by"`                  // Инициатор
+	DriverProfileId DriverID             `json:"driver_profile_id,omitempty"` // Идентификатор профиля водителя
+	OrderId         OrderID              `json:"order_id,omitempty"`          // Идентификатор заказа
+}
+
+type GetTransactionsListArgs struct {
+	ParkID      ParkID
+	DriverID    DriverID  // Водитель. Обязателен для GetDriverTransactions
+	Cursor      string    // Курсор следующей страницы из GetTransactionsListResult.Cursor. Пустая строка - первая страница
+	Limit       int       // Размер страницы. По умолчанию 1000
+	EventAt     TimeRange // Полуинтервал времени транзакции. Обе границы обязательны
+	CategoryIDs []string  // Фильтр по категориям транзакций. Пустой - все категории
+}
+
+type GetTransactionsListResult struct {
+	Cursor       string        `json:"cursor"`       // Курсор следующей страницы. Пустая строка - страниц больше нет
+	Transactions []Transaction `json:"transactions"` // Список транзакций
+
+	Meta ResponseMeta `json:"-"` // Сведения о происхождении ответа
 }
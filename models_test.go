@@ -0,0 +1,64 @@
+package yandex_taxi_go
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetDriverProfilesResult_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	const body = `{
+		"total": 1, "offset": 0, "limit": 1000,
+		"parks": [{"id": "park-id", "city": "Москва", "name": "Park"}],
+		"driver_profiles": [{
+			"accounts": [{"id": "acc-id", "balance": "1000.00", "balance_limit": "50.00", "currency": "RUB", "type": "current"}],
+			"car": {
+				"id": "car-id", "amenities": ["wifi"], "brand": "Kia", "callsign": "123", "category": ["econom"],
+				"color": "Белый", "model": "Rio", "number": "А001АА77", "registration_cert": "99 00 000000",
+				"status": "working", "vin": "XTA00000000000000", "year": 2019
+			},
+			"current_status": {"status": "busy", "status_updated_at": "2020-04-27T08:44:05.871+0000"},
+			"driver_profile": {
+				"id": "driver-id", "check_message": "check", "comment": "comment", "created_date": "2020-04-23T13:08:05.552+0000",
+				"driver_license": {
+					"issue_date": "2020-10-28", "expiration_date": "2050-10-28", "number": "07 02 36",
+					"normalized_number": "070236", "country": "rus", "birth_date": "1975-10-28"
+				},
+				"employment_type": "selfemployed", "first_name": "Иван", "has_contract_issue": true,
+				"last_name": "Иванов", "middle_name": "Иванович", "park_id": "park-id",
+				"phones": ["+79999999999"], "work_rule_id": "rule-id", "work_status": "working"
+			}
+		}]
+	}`
+
+	var got GetDriverProfilesResult
+	require.NoError(t, json.Unmarshal([]byte(body), &got))
+
+	require.Equal(t, GetDriverProfilesResult{
+		Total:  1,
+		Offset: 0,
+		Limit:  1000,
+		Parks:  []DriverProfilePark{{Id: "park-id", City: "Москва", Name: "Park"}},
+		DriverProfiles: []DriverProfile{{
+			Accounts: []DriverProfileAccount{{Id: "acc-id", Balance: "1000.00", BalanceLimit: "50.00", Currency: "RUB", Type: "current"}},
+			Car: &Vehicle{
+				Id: "car-id", Amenities: []string{"wifi"}, Brand: "Kia", Callsign: "123", Category: []string{"econom"},
+				Color: "Белый", Model: "Rio", Number: "А001АА77", RegistrationCert: "99 00 000000",
+				Status: "working", Vin: "XTA00000000000000", Year: 2019,
+			},
+			CurrentStatus: &DriverProfileCurrentStatus{Status: "busy", StatusUpdatedAt: "2020-04-27T08:44:05.871+0000"},
+			Profile: &DriverProfileData{
+				Id: "driver-id", CheckMessage: "check", Comment: "comment", CreatedDate: "2020-04-23T13:08:05.552+0000",
+				DriverLicense: DriverLicense{
+					IssueDate: "2020-10-28", ExpirationDate: "2050-10-28", Number: "07 02 36",
+					NormalizedNumber: "070236", Country: "rus", BirthDate: "1975-10-28",
+				},
+				EmploymentType: "selfemployed", FirstName: "Иван", HasContractIssue: true,
+				LastName: "Иванов", MiddleName: "Иванович", ParkId: "park-id",
+				Phones: []string{"+79999999999"}, WorkRuleId: "rule-id", WorkStatus: "working",
+			},
+		}},
+	}, got)
+}
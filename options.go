@@ -0,0 +1,66 @@
+package yandex_taxi_go
+
+import (
+	"net/http"
+	"time"
+)
+
+// CallOption Параметр отдельного вызова метода API
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	language         string
+	timeout          time.Duration
+	headers          http.Header
+	idempotencyToken string
+	noCache          bool
+}
+
+// WithCallLanguage Язык ответа для одного вызова. Переопределяет значение, заданное через WithLanguage
+func WithCallLanguage(language string) CallOption {
+	return func(o *callOptions) {
+		o.language = language
+	}
+}
+
+// WithCallTimeout Ограничение времени выполнения одного вызова
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithCallHeader Дополнительный заголовок запроса. Служебные заголовки клиента
+// (Content-Type, X-API-Key, X-Client-ID) переопределить нельзя
+func WithCallHeader(key, value string) CallOption {
+	return func(o *callOptions) {
+		if o.headers == nil {
+			o.headers = make(http.Header)
+		}
+		o.headers.Add(key, value)
+	}
+}
+
+// WithIdempotencyToken Токен идемпотентности (заголовок X-Idempotency-Token)
+func WithIdempotencyToken(token string) CallOption {
+	return func(o *callOptions) {
+		o.idempotencyToken = token
+	}
+}
+
+// WithCallNoCache Выполняет запрос к API, не используя свежие данные из кэша. Полученный ответ сохраняется в кэш
+func WithCallNoCache() CallOption {
+	return func(o *callOptions) {
+		o.noCache = true
+	}
+}
+
+func (c *Client) callOptions(opts []CallOption) callOptions {
+	o := callOptions{
+		language: c.language,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,112 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_CallOptions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+	)
+
+	newServer := func(t *testing.T, check func(r *http.Request)) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			check(r)
+
+			bytes, _ := json.Marshal(GetDriverProfilesResult{})
+			_, err := w.Write(bytes)
+			require.NoError(t, err)
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	t.Run("client language", func(t *testing.T) {
+		t.Parallel()
+
+		server := newServer(t, func(r *http.Request) {
+			require.Equal(t, "kk", r.Header.Get(headerAcceptLanguage))
+		})
+
+		c := NewClient(ClientConfig{
+			ClientID: testClientID,
+			APIKey:   testAPIKey,
+		}, WithAPIHost(server.URL), WithLanguage("kk"))
+
+		_, err := c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-id"})
+		require.NoError(t, err)
+		_, err = c.GetDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id"})
+		require.NoError(t, err)
+	})
+
+	t.Run("no language", func(t *testing.T) {
+		t.Parallel()
+
+		server := newServer(t, func(r *http.Request) {
+			require.Empty(t, r.Header.Values(headerAcceptLanguage))
+		})
+
+		c := NewClient(ClientConfig{
+			ClientID: testClientID,
+			APIKey:   testAPIKey,
+		}, WithAPIHost(server.URL), WithLanguage(""))
+
+		_, err := c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-id"})
+		require.NoError(t, err)
+	})
+
+	t.Run("per call options", func(t *testing.T) {
+		t.Parallel()
+
+		server := newServer(t, func(r *http.Request) {
+			require.Equal(t, "uz", r.Header.Get(headerAcceptLanguage))
+			require.Equal(t, "token-1", r.Header.Get(headerXIdempotencyToken))
+			require.Equal(t, "request-1", r.Header.Get("X-Request-ID"))
+			require.Equal(t, testAPIKey, r.Header.Get(headerXAPIKey))
+			require.Equal(t, contentTypeJson, r.Header.Get(headerContentType))
+		})
+
+		c := NewClient(ClientConfig{
+			ClientID: testClientID,
+			APIKey:   testAPIKey,
+		}, WithAPIHost(server.URL), WithLanguage("kk"))
+
+		_, err := c.GetDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id"},
+			WithCallLanguage("uz"),
+			WithIdempotencyToken("token-1"),
+			WithCallHeader("X-Request-ID", "request-1"),
+			WithCallHeader(headerXAPIKey, "other-key"),
+			WithCallHeader(headerContentType, "text/plain"),
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		server := newServer(t, func(r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(200 * time.Millisecond):
+			}
+		})
+
+		c := NewClient(ClientConfig{
+			ClientID: testClientID,
+			APIKey:   testAPIKey,
+		}, WithAPIHost(server.URL))
+
+		_, err := c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-id"}, WithCallTimeout(10*time.Millisecond))
+		require.Error(t, err)
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+}
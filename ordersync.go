@@ -0,0 +1,177 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultOrderWindow  = time.Hour
+	defaultOrderOverlap = 2 * time.Hour
+)
+
+// OrderSyncConfig Параметры OrderSync
+type OrderSyncConfig struct {
+	ParkID      ParkID
+	Checkpoints CheckpointStore  // Хранилище позиции синхронизации. По умолчанию в памяти
+	Statuses    []string         // Статусы заказов, которые передаются обработчику. По умолчанию только "complete"
+	Start       time.Time        // Время бронирования, с которого начинается первая синхронизация. По умолчанию время первого запуска минус Overlap
+	Window      time.Duration    // Длина окна по времени бронирования, которое обходится одним курсором. По умолчанию один час
+	Overlap     time.Duration    // Насколько раньше отметки перечитывать заказы, которые достигли нужного статуса после прошлого прохода. По умолчанию два часа
+	PageLimit   int              // Размер страницы. По умолчанию 500
+	Interval    time.Duration    // Период опроса в Run. По умолчанию и при отрицательном значении одна минута
+	Now         func() time.Time // Источник текущего времени. По умолчанию time.Now
+}
+
+// OrderSync Инкрементальная синхронизация заказов парка по времени бронирования. Проход обходит
+// окна длиной cfg.Window от отметки до текущего времени, каждое окно читается курсором API.
+// Заказ попадает в список по времени бронирования, а завершается позже, поэтому каждый проход
+// начинается на cfg.Overlap раньше отметки. Повторно прочитанные заказы отбрасываются по
+// идентификатору: идентификаторы переданных обработчику заказов из окна перекрытия хранятся
+// в позиции синхронизации.
+// Позиция сохраняется после каждой страницы и после ошибки обработчика, поэтому после сбоя
+// синхронизация продолжается с той же страницы. Повторно заказ передается, только если процесс
+// остановился между вызовом обработчика и сохранением позиции; обработчику, который пишет
+// во внешнее хранилище, стоит быть идемпотентным по Order.Id
+type OrderSync struct {
+	client *Client
+	cfg    OrderSyncConfig
+	mu     sync.Mutex
+}
+
+// NewOrderSync Создает синхронизацию заказов парка cfg.ParkID
+func NewOrderSync(client *Client, cfg OrderSyncConfig) *OrderSync {
+	if cfg.Checkpoints == nil {
+		cfg.Checkpoints = NewMemoryCheckpointStore()
+	}
+	if len(cfg.Statuses) == 0 {
+		cfg.Statuses = []string{"complete"}
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaultOrderWindow
+	}
+	if cfg.Overlap == 0 {
+		cfg.Overlap = defaultOrderOverlap
+	}
+	if cfg.PageLimit == 0 {
+		cfg.PageLimit = defaultOrderPageLimit
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultSyncInterval
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	return &OrderSync{client: client, cfg: cfg}
+}
+
+func (s *OrderSync) checkpointKey() string {
+	return "orders/" + string(s.cfg.ParkID)
+}
+
+// Sync Выполняет один проход синхронизации и возвращает число переданных обработчику заказов.
+// Ошибка обработчика прерывает проход; следующий проход продолжит с заказа, на котором произошла ошибка
+func (s *OrderSync) Sync(ctx context.Context, fn func(Order) error) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.cfg.Now()
+	cp, from, err := startCursorSyncPass(ctx, s.cfg.Checkpoints, s.checkpointKey(), s.cfg.Start, now, s.cfg.Overlap)
+	if err != nil {
+		return 0, err
+	}
+
+	p := &orderSyncPass{sync: s, cp: cp, fn: fn}
+
+	if window, cursor, ok := cp.interrupted(); ok {
+		// Обход окна прервался: окно дочитывается с сохраненного курсора
+		if err = p.window(ctx, window, cursor); err != nil {
+			return p.events, err
+		}
+		from = window.To
+	}
+
+	for from.Before(now) {
+		to := from.Add(s.cfg.Window)
+		if to.After(now) {
+			to = now
+		}
+		if err = p.window(ctx, TimeRange{From: from, To: to}, ""); err != nil {
+			return p.events, err
+		}
+		from = to
+	}
+
+	return p.events, nil
+}
+
+// orderSyncPass Состояние одного прохода OrderSync
+type orderSyncPass struct {
+	sync   *OrderSync
+	cp     *cursorSyncPass
+	fn     func(Order) error
+	events int
+}
+
+// window Читает окно времени бронирования, начиная с курсора cursor
+func (p *orderSyncPass) window(ctx context.Context, window TimeRange, cursor string) error {
+	cfg := p.sync.cfg
+	args := GetOrdersListArgs{
+		ParkID:   cfg.ParkID,
+		Cursor:   cursor,
+		Limit:    cfg.PageLimit,
+		BookedAt: window,
+		Statuses: cfg.Statuses,
+	}
+
+	for {
+		page, err := p.sync.client.GetOrdersList(ctx, args)
+		if err != nil {
+			return err
+		}
+
+		for _, order := range page.Orders {
+			if p.cp.seen(string(order.Id)) {
+				continue
+			}
+			bookedAt, err := ParseTime(order.BookedAt)
+			if err != nil {
+				return fmt.Errorf("order %s: booked_at: %w", order.Id, err)
+			}
+
+			if err = p.fn(order); err != nil {
+				// Уже переданные заказы страницы запоминаются, чтобы повторный проход их пропустил
+				if saveErr := p.cp.save(ctx); saveErr != nil {
+					return fmt.Errorf("%w; %v", err, saveErr)
+				}
+				return err
+			}
+			p.cp.mark(string(order.Id), bookedAt)
+			p.events++
+		}
+
+		if page.Cursor == "" || len(page.Orders) == 0 {
+			break
+		}
+
+		args.Cursor = page.Cursor
+		if err = p.cp.commit(ctx, window, page.Cursor); err != nil {
+			return err
+		}
+	}
+
+	p.cp.advance(window.To)
+	return p.cp.commit(ctx, window, "")
+}
+
+// Run Повторяет Sync каждые cfg.Interval, пока не отменен ctx. Неудачный проход записывается
+// в журнал клиента, следующий выполняется по расписанию
+func (s *OrderSync) Run(ctx context.Context, fn func(Order) error) error {
+	return s.client.runEvery(ctx, s.cfg.Interval, "fleet order sync failed", s.cfg.ParkID, func(ctx context.Context) error {
+		_, err := s.Sync(ctx, fn)
+		return err
+	})
+}
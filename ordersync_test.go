@@ -0,0 +1,149 @@
+package yandex_taxi_go_test
+
+import (
+	"context"
+	"errors"
+	fleet "github.com/sinland/yandex-taxi-go"
+	"github.com/sinland/yandex-taxi-go/fleettest"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func putOrder(t *testing.T, s *fleettest.Server, id fleet.OrderID, status string, bookedAt time.Time) {
+	require.NoError(t, s.PutOrder("park-1", fleet.Order{Id: id, Status: status, BookedAt: bookedAt.Format(time.RFC3339)}))
+}
+
+func collectOrders(ids *[]fleet.OrderID) func(fleet.Order) error {
+	return func(o fleet.Order) error {
+		*ids = append(*ids, o.Id)
+		return nil
+	}
+}
+
+func TestOrderSync(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, clock, c := newSyncServer(t)
+	now := clock.Now()
+
+	putOrder(t, s, "order-1", "complete", now.Add(-170*time.Minute))
+	putOrder(t, s, "order-2", "cancelled", now.Add(-160*time.Minute))
+	putOrder(t, s, "order-3", "complete", now.Add(-150*time.Minute))
+	putOrder(t, s, "order-4", "complete", now.Add(-105*time.Minute))
+	putOrder(t, s, "order-5", "driving", now.Add(-10*time.Minute))
+	putOrder(t, s, "order-6", "complete", now.Add(-20*time.Minute))
+
+	checkpoints := fleet.NewMemoryCheckpointStore()
+	sync := fleet.NewOrderSync(c, fleet.OrderSyncConfig{
+		ParkID:      "park-1",
+		Checkpoints: checkpoints,
+		Start:       now.Add(-3 * time.Hour),
+		Window:      time.Hour,
+		Overlap:     30 * time.Minute,
+		PageLimit:   1,
+		Now:         clock.Now,
+	})
+
+	var ids []fleet.OrderID
+	n, err := sync.Sync(ctx, collectOrders(&ids))
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+	require.Equal(t, []fleet.OrderID{"order-1", "order-3", "order-4", "order-6"}, ids)
+
+	cp, found, err := checkpoints.Load(ctx, "orders/park-1")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.True(t, now.Equal(cp.Watermark))
+	require.Empty(t, cp.Cursor)
+	require.Nil(t, cp.Window)
+	require.Len(t, cp.Seen, 1, "only orders inside the overlap window are remembered")
+	require.Contains(t, cp.Seen, "order-6")
+
+	t.Run("no new orders", func(t *testing.T) {
+		clock.Advance(time.Minute)
+
+		ids = nil
+		n, err = sync.Sync(ctx, collectOrders(&ids))
+		require.NoError(t, err)
+		require.Zero(t, n)
+	})
+
+	t.Run("late completion inside overlap", func(t *testing.T) {
+		clock.Advance(5 * time.Minute)
+		putOrder(t, s, "order-5", "complete", now.Add(-10*time.Minute))
+		// Заказ забронирован раньше окна перекрытия и уже не будет прочитан
+		putOrder(t, s, "order-2", "complete", now.Add(-160*time.Minute))
+		putOrder(t, s, "order-7", "complete", clock.Now().Add(-time.Minute))
+
+		ids = nil
+		n, err = sync.Sync(ctx, collectOrders(&ids))
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.Equal(t, []fleet.OrderID{"order-5", "order-7"}, ids)
+	})
+}
+
+func TestOrderSync_Resume(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, clock, c := newSyncServer(t)
+	now := clock.Now()
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+
+	all := []fleet.OrderID{"order-1", "order-2", "order-3", "order-4", "order-5"}
+	for i, id := range all {
+		putOrder(t, s, id, "complete", now.Add(-time.Hour+time.Duration(i)*time.Minute))
+	}
+
+	cfg := fleet.OrderSyncConfig{
+		ParkID:      "park-1",
+		Checkpoints: fleet.NewFileCheckpointStore(path),
+		Start:       now.Add(-2 * time.Hour),
+		PageLimit:   2,
+		Now:         clock.Now,
+	}
+
+	var delivered []fleet.OrderID
+	errHandler := errors.New("warehouse unavailable")
+	n, err := fleet.NewOrderSync(c, cfg).Sync(ctx, func(o fleet.Order) error {
+		if o.Id == "order-4" {
+			return errHandler
+		}
+		delivered = append(delivered, o.Id)
+		return nil
+	})
+	require.ErrorIs(t, err, errHandler)
+	require.Equal(t, 3, n)
+
+	// Новый процесс с тем же файлом продолжает с прерванной страницы
+	cfg.Checkpoints = fleet.NewFileCheckpointStore(path)
+	cp, found, err := cfg.Checkpoints.Load(ctx, "orders/park-1")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.NotEmpty(t, cp.Cursor)
+	require.NotNil(t, cp.Window)
+
+	requests := s.Requests(fleet.EndpointOrdersList)
+	n, err = fleet.NewOrderSync(c, cfg).Sync(ctx, collectOrders(&delivered))
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, all, delivered)
+	require.Equal(t, 2, s.Requests(fleet.EndpointOrdersList)-requests)
+}
+
+func TestOrderSync_RunNegativeInterval(t *testing.T) {
+	t.Parallel()
+
+	s, clock, c := newSyncServer(t)
+	putOrder(t, s, "order-1", "complete", clock.Now().Add(-time.Hour))
+
+	sync := fleet.NewOrderSync(c, fleet.OrderSyncConfig{ParkID: "park-1", Interval: -time.Second, Now: clock.Now})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, sync.Run(ctx, func(fleet.Order) error { return nil }), context.Canceled)
+}
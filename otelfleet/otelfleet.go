@@ -0,0 +1,117 @@
+// Package otelfleet Инструментирование клиента Fleet API с помощью OpenTelemetry.
+// Вынесено в отдельный пакет, чтобы основной пакет не зависел от OpenTelemetry
+package otelfleet
+
+import (
+	"context"
+	"errors"
+	fleet "github.com/sinland/yandex-taxi-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"net"
+	"net/http"
+	"strconv"
+)
+
+const instrumentationName = "github.com/sinland/yandex-taxi-go/otelfleet"
+
+// Атрибуты спанов
+const (
+	AttrParkID     = attribute.Key("fleet.park_id")
+	AttrPageOffset = attribute.Key("fleet.page.offset")
+	AttrStatusCode = attribute.Key("http.response.status_code")
+	AttrErrorType  = attribute.Key("error.type")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	propagator     propagation.TextMapPropagator
+}
+
+// Option Параметр инструментирования
+type Option func(*config)
+
+// WithTracerProvider Провайдер трассировки. По умолчанию otel.GetTracerProvider()
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithPropagator Способ передачи контекста трассировки в заголовках запроса.
+// По умолчанию W3C Trace Context
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = p
+	}
+}
+
+// Middleware Создает middleware, который оборачивает каждый вызов API в спан с именем метода API
+// и передает контекст трассировки в заголовках запроса. Подключается через fleet.WithMiddleware
+func Middleware(opts ...Option) fleet.Middleware {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		propagator:     propagation.TraceContext{},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(instrumentationName)
+
+	return func(next fleet.RoundTrip) fleet.RoundTrip {
+		return func(req *http.Request) (*http.Response, error) {
+			info, _ := fleet.CallInfoFromContext(req.Context())
+
+			spanName := info.Endpoint
+			if spanName == "" {
+				spanName = req.URL.Path
+			}
+
+			ctx, span := tracer.Start(req.Context(), spanName,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					AttrParkID.String(string(info.ParkID)),
+					AttrPageOffset.Int(info.Offset),
+				),
+			)
+			defer span.End()
+
+			req = req.WithContext(ctx)
+			cfg.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			res, err := next(req)
+			if err != nil {
+				span.SetAttributes(AttrErrorType.String(transportErrorClass(err)))
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return nil, err
+			}
+
+			span.SetAttributes(AttrStatusCode.Int(res.StatusCode))
+			if res.StatusCode >= http.StatusBadRequest {
+				span.SetAttributes(AttrErrorType.String(strconv.Itoa(res.StatusCode)))
+				span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+			}
+
+			return res, nil
+		}
+	}
+}
+
+func transportErrorClass(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	default:
+		return "transport"
+	}
+}
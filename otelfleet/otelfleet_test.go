@@ -0,0 +1,120 @@
+package otelfleet
+
+import (
+	"context"
+	fleet "github.com/sinland/yandex-taxi-go"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+	)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		var traceparent string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			traceparent = r.Header.Get("traceparent")
+			_, err := w.Write([]byte(`{"total":0,"offset":2000,"limit":1000,"cars":[]}`))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		recorder := tracetest.NewSpanRecorder()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+		c := fleet.NewClient(fleet.ClientConfig{ClientID: "client-id", APIKey: "api-key"},
+			fleet.WithAPIHost(server.URL),
+			fleet.WithMiddleware(Middleware(WithTracerProvider(tp))),
+		)
+
+		_, err := c.GetCarsList(ctx, fleet.GetCarsListArgs{ParkID: "park-id", Page: 2})
+		require.NoError(t, err)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+
+		span := spans[0]
+		require.Equal(t, "/v1/parks/cars/list", span.Name())
+		require.Equal(t, trace.SpanKindClient, span.SpanKind())
+		require.Equal(t, codes.Unset, span.Status().Code)
+
+		attrs := attribute.NewSet(span.Attributes()...)
+		parkID, _ := attrs.Value(AttrParkID)
+		require.Equal(t, "park-id", parkID.AsString())
+		offset, _ := attrs.Value(AttrPageOffset)
+		require.Equal(t, int64(2000), offset.AsInt64())
+		status, _ := attrs.Value(AttrStatusCode)
+		require.Equal(t, int64(http.StatusOK), status.AsInt64())
+
+		require.NotEmpty(t, traceparent)
+		require.Contains(t, traceparent, span.SpanContext().TraceID().String())
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, err := w.Write([]byte(`{"code":"429","message":"Too many requests"}`))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		recorder := tracetest.NewSpanRecorder()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+		c := fleet.NewClient(fleet.ClientConfig{ClientID: "client-id", APIKey: "api-key"},
+			fleet.WithAPIHost(server.URL),
+			fleet.WithMiddleware(Middleware(WithTracerProvider(tp))),
+		)
+
+		_, err := c.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{ParkID: "park-id"})
+		require.Error(t, err)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		require.Equal(t, "/v1/parks/driver-profiles/list", spans[0].Name())
+		require.Equal(t, codes.Error, spans[0].Status().Code)
+
+		attrs := attribute.NewSet(spans[0].Attributes()...)
+		errType, _ := attrs.Value(AttrErrorType)
+		require.Equal(t, "429", errType.AsString())
+	})
+
+	t.Run("transport error", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := tracetest.NewSpanRecorder()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		c := fleet.NewClient(fleet.ClientConfig{ClientID: "client-id", APIKey: "api-key"},
+			fleet.WithAPIHost("http://127.0.0.1:1"),
+			fleet.WithMiddleware(Middleware(WithTracerProvider(tp))),
+		)
+
+		_, err := c.GetCarsList(cctx, fleet.GetCarsListArgs{ParkID: "park-id"})
+		require.Error(t, err)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		attrs := attribute.NewSet(spans[0].Attributes()...)
+		errType, _ := attrs.Value(AttrErrorType)
+		require.Equal(t, "canceled", errType.AsString())
+	})
+}
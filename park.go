@@ -0,0 +1,133 @@
+package yandex_taxi_go
+
+import "context"
+
+// ParkClient Клиент, привязанный к одному парку. Методы повторяют методы Client без указания парка
+type ParkClient struct {
+	client *Client
+	parkID ParkID
+}
+
+// Park Возвращает клиент для парка parkID
+func (c *Client) Park(parkID ParkID) *ParkClient {
+	return &ParkClient{
+		client: c,
+		parkID: parkID,
+	}
+}
+
+// ID Идентификатор парка
+func (p *ParkClient) ID() ParkID {
+	return p.parkID
+}
+
+// Client Клиент, к которому привязан парк
+func (p *ParkClient) Client() *Client {
+	return p.client
+}
+
+// ParkCarsListArgs Параметры GetCarsList для ParkClient
+type ParkCarsListArgs struct {
+	Page  int
+	Limit int
+}
+
+// GetCarsList Получение списка автомобилей парка
+func (p *ParkClient) GetCarsList(ctx context.Context, args ParkCarsListArgs, opts ...CallOption) (*GetCarsListResult, error) {
+	return p.client.GetCarsList(ctx, GetCarsListArgs{
+		ParkID: p.parkID,
+		Page:   args.Page,
+		Limit:  args.Limit,
+	}, opts...)
+}
+
+// ParkDriverProfilesArgs Параметры GetDriverProfiles для ParkClient
+type ParkDriverProfilesArgs struct {
+	Offset    int
+	Limit     int
+	QueryText string
+	UpdatedAt *TimeRange
+	Fields    *DriverProfileFields
+	SortOrder []DriverProfileSortOrder
+}
+
+func (p *ParkClient) driverProfilesArgs(args ParkDriverProfilesArgs) GetDriverProfilesArgs {
+	return GetDriverProfilesArgs{
+		ParkID:    p.parkID,
+		Offset:    args.Offset,
+		Limit:     args.Limit,
+		QueryText: args.QueryText,
+		UpdatedAt: args.UpdatedAt,
+		Fields:    args.Fields,
+		SortOrder: args.SortOrder,
+	}
+}
+
+// GetDriverProfiles Получение списка профилей водителей парка
+func (p *ParkClient) GetDriverProfiles(ctx context.Context, args ParkDriverProfilesArgs, opts ...CallOption) (*GetDriverProfilesResult, error) {
+	return p.client.GetDriverProfiles(ctx, p.driverProfilesArgs(args), opts...)
+}
+
+// StreamDriverProfiles Потоковое получение списка профилей водителей парка
+func (p *ParkClient) StreamDriverProfiles(ctx context.Context, args ParkDriverProfilesArgs, fn func(DriverProfile) error, opts ...CallOption) (*DriverProfilesPage, error) {
+	return p.client.StreamDriverProfiles(ctx, p.driverProfilesArgs(args), fn, opts...)
+}
+
+// ScanDriverProfiles Параллельная загрузка всех профилей водителей парка
+func (p *ParkClient) ScanDriverProfiles(ctx context.Context, args ParkDriverProfilesArgs, concurrency int, opts ...CallOption) (*GetDriverProfilesResult, error) {
+	return p.client.ScanDriverProfiles(ctx, p.driverProfilesArgs(args), concurrency, opts...)
+}
+
+// SnapshotDriverProfiles Полный список профилей водителей парка с защитой от сдвигов пагинации
+func (p *ParkClient) SnapshotDriverProfiles(ctx context.Context, args ParkDriverProfilesArgs, snap SnapshotOptions, opts ...CallOption) (*DriverProfilesSnapshot, error) {
+	return p.client.SnapshotDriverProfiles(ctx, p.driverProfilesArgs(args), snap, opts...)
+}
+
+// ParkOrdersListArgs Параметры GetOrdersList для ParkClient
+type ParkOrdersListArgs struct {
+	Cursor   string
+	Limit    int
+	BookedAt TimeRange
+	Statuses []string
+}
+
+// GetOrdersList Получение страницы списка заказов парка
+func (p *ParkClient) GetOrdersList(ctx context.Context, args ParkOrdersListArgs, opts ...CallOption) (*GetOrdersListResult, error) {
+	return p.client.GetOrdersList(ctx, GetOrdersListArgs{
+		ParkID:   p.parkID,
+		Cursor:   args.Cursor,
+		Limit:    args.Limit,
+		BookedAt: args.BookedAt,
+		Statuses: args.Statuses,
+	}, opts...)
+}
+
+// ParkTransactionsArgs Параметры GetTransactions и GetDriverTransactions для ParkClient
+type ParkTransactionsArgs struct {
+	Cursor      string
+	Limit       int
+	EventAt     TimeRange
+	CategoryIDs []string
+}
+
+func (p *ParkClient) transactionsArgs(args ParkTransactionsArgs) GetTransactionsListArgs {
+	return GetTransactionsListArgs{
+		ParkID:      p.parkID,
+		Cursor:      args.Cursor,
+		Limit:       args.Limit,
+		EventAt:     args.EventAt,
+		CategoryIDs: args.CategoryIDs,
+	}
+}
+
+// GetTransactions Получение страницы списка транзакций парка
+func (p *ParkClient) GetTransactions(ctx context.Context, args ParkTransactionsArgs, opts ...CallOption) (*GetTransactionsListResult, error) {
+	return p.client.GetParkTransactions(ctx, p.transactionsArgs(args), opts...)
+}
+
+// GetDriverTransactions Получение страницы списка транзакций водителя парка
+func (p *ParkClient) GetDriverTransactions(ctx context.Context, driverID DriverID, args ParkTransactionsArgs, opts ...CallOption) (*GetTransactionsListResult, error) {
+	txArgs := p.transactionsArgs(args)
+	txArgs.DriverID = driverID
+	return p.client.GetDriverTransactions(ctx, txArgs, opts...)
+}
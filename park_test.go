@@ -0,0 +1,65 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/sinland/yandex-taxi-go/internal/models"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParkClient(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case EndpointCarsList:
+			var req models.CarsListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "park-id", req.Query.Park.Id)
+			require.Equal(t, 20, req.Offset)
+			require.Equal(t, 10, req.Limit)
+
+			bytes, _ := json.Marshal(GetCarsListResult{Total: 21, Offset: req.Offset, Limit: req.Limit})
+			_, _ = w.Write(bytes)
+		case EndpointDriverProfilesList:
+			var req models.DriverProfilesRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "park-id", req.Query.Park.Id)
+			require.Equal(t, 5, req.Offset)
+			require.Equal(t, "Ivanov", req.Query.Text)
+			require.Equal(t, []models.DriverProfileRequestSortOrderField{{Direction: "desc", Field: "driver_profile.id"}}, req.SortOrder)
+
+			bytes, _ := json.Marshal(GetDriverProfilesResult{Total: 6, Offset: req.Offset, Limit: req.Limit})
+			_, _ = w.Write(bytes)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	park := NewClient(ClientConfig{
+		ClientID: testClientID,
+		APIKey:   testAPIKey,
+	}, WithAPIHost(server.URL)).Park("park-id")
+
+	require.Equal(t, ParkID("park-id"), park.ID())
+
+	cars, err := park.GetCarsList(ctx, ParkCarsListArgs{Page: 2, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 21, cars.Total)
+
+	drivers, err := park.GetDriverProfiles(ctx, ParkDriverProfilesArgs{
+		Offset:    5,
+		QueryText: "Ivanov",
+		SortOrder: []DriverProfileSortOrder{{Field: "driver_profile.id", Direction: "desc"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 6, drivers.Total)
+}
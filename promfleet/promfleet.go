@@ -0,0 +1,96 @@
+// Package promfleet Адаптер метрик клиента Fleet API для Prometheus
+package promfleet
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	fleet "github.com/sinland/yandex-taxi-go"
+	"time"
+)
+
+const (
+	labelEndpoint    = "endpoint"
+	labelStatusClass = "status_class"
+)
+
+// Metrics Реализация fleet.Metrics на основе коллекторов Prometheus
+type Metrics struct {
+	requests      *prometheus.CounterVec
+	duration      *prometheus.HistogramVec
+	inFlight      *prometheus.GaugeVec
+	retries       *prometheus.CounterVec
+	rateLimitWait *prometheus.HistogramVec
+}
+
+var _ fleet.Metrics = (*Metrics)(nil)
+
+// Config Параметры метрик
+type Config struct {
+	Namespace string    // Префикс имен метрик, по умолчанию fleet_api
+	Buckets   []float64 // Границы гистограмм длительности в секундах, по умолчанию prometheus.DefBuckets
+}
+
+// NewMetrics Создает метрики и регистрирует их в reg
+func NewMetrics(reg prometheus.Registerer, cfg Config) (*Metrics, error) {
+	if cfg.Namespace == "" {
+		cfg.Namespace = "fleet_api"
+	}
+	if cfg.Buckets == nil {
+		cfg.Buckets = prometheus.DefBuckets
+	}
+
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests to Fleet API, including retries.",
+		}, []string{labelEndpoint, labelStatusClass}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.Namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of HTTP requests to Fleet API.",
+			Buckets:   cfg.Buckets,
+		}, []string{labelEndpoint, labelStatusClass}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: cfg.Namespace,
+			Name:      "requests_in_flight",
+			Help:      "Number of HTTP requests to Fleet API in progress.",
+		}, []string{labelEndpoint}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Name:      "retries_total",
+			Help:      "Number of retried requests to Fleet API by status class of the failed attempt.",
+		}, []string{labelEndpoint, labelStatusClass}),
+		rateLimitWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.Namespace,
+			Name:      "rate_limit_wait_seconds",
+			Help:      "Time spent waiting for the client rate limiter.",
+			Buckets:   cfg.Buckets,
+		}, []string{labelEndpoint}),
+	}
+
+	for _, c := range []prometheus.Collector{m.requests, m.duration, m.inFlight, m.retries, m.rateLimitWait} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Metrics) RequestStarted(endpoint string) {
+	m.inFlight.WithLabelValues(endpoint).Inc()
+}
+
+func (m *Metrics) RequestFinished(endpoint, statusClass string, duration time.Duration) {
+	m.inFlight.WithLabelValues(endpoint).Dec()
+	m.requests.WithLabelValues(endpoint, statusClass).Inc()
+	m.duration.WithLabelValues(endpoint, statusClass).Observe(duration.Seconds())
+}
+
+func (m *Metrics) Retry(endpoint, statusClass string) {
+	m.retries.WithLabelValues(endpoint, statusClass).Inc()
+}
+
+func (m *Metrics) RateLimitWait(endpoint string, wait time.Duration) {
+	m.rateLimitWait.WithLabelValues(endpoint).Observe(wait.Seconds())
+}
@@ -0,0 +1,83 @@
+package promfleet
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	fleet "github.com/sinland/yandex-taxi-go"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, err := w.Write([]byte(`{"total":0,"offset":0,"limit":1000,"cars":[]}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	reg := prometheus.NewPedanticRegistry()
+	metrics, err := NewMetrics(reg, Config{})
+	require.NoError(t, err)
+
+	c := fleet.NewClient(fleet.ClientConfig{ClientID: "client-id", APIKey: "api-key"},
+		fleet.WithAPIHost(server.URL),
+		fleet.WithMetrics(metrics),
+		fleet.WithRetry(fleet.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}),
+		fleet.WithRateLimit(1000, 10),
+	)
+
+	_, err = c.GetCarsList(context.Background(), fleet.GetCarsListArgs{ParkID: "park-id"})
+	require.NoError(t, err)
+
+	const endpoint = "/v1/parks/cars/list"
+
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues(endpoint, "5xx")))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues(endpoint, "2xx")))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.retries.WithLabelValues(endpoint, "5xx")))
+	require.Equal(t, 0.0, testutil.ToFloat64(metrics.inFlight.WithLabelValues(endpoint)))
+
+	count, err := testutil.GatherAndCount(reg,
+		"fleet_api_requests_total",
+		"fleet_api_request_duration_seconds",
+		"fleet_api_requests_in_flight",
+		"fleet_api_retries_total",
+		"fleet_api_rate_limit_wait_seconds",
+	)
+	require.NoError(t, err)
+	require.Equal(t, 7, count)
+
+	expected := `
+# HELP fleet_api_requests_total Number of HTTP requests to Fleet API, including retries.
+# TYPE fleet_api_requests_total counter
+fleet_api_requests_total{endpoint="/v1/parks/cars/list",status_class="2xx"} 1
+fleet_api_requests_total{endpoint="/v1/parks/cars/list",status_class="5xx"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "fleet_api_requests_total"))
+}
+
+func TestNewMetrics_DuplicateRegistration(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	_, err := NewMetrics(reg, Config{})
+	require.NoError(t, err)
+
+	_, err = NewMetrics(reg, Config{})
+	require.Error(t, err)
+
+	_, err = NewMetrics(reg, Config{Namespace: "other"})
+	require.NoError(t, err)
+}
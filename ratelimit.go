@@ -0,0 +1,101 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WithRateLimit Ограничивает частоту запросов клиента: не более rps запросов в секунду
+// с возможностью кратковременного всплеска до burst запросов. rps <= 0 или бесконечность - без ограничения
+func WithRateLimit(rps float64, burst int) func(client *Client) {
+	return func(s *Client) {
+		s.limiter = newRateLimiter(rps, burst)
+	}
+}
+
+// rateLimiter Ограничитель частоты запросов по алгоритму token bucket
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter Создает ограничитель. Возвращает nil, если rps не задает ограничения
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	if !(rps > 0) || math.IsInf(rps, 1) {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve Забирает токен и возвращает время, через которое его можно использовать
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel Возвращает токен, если ожидание было прервано
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+}
+
+// Wait Ожидает возможности отправить запрос и возвращает время ожидания
+func (l *rateLimiter) Wait(ctx context.Context) (time.Duration, error) {
+	delay := l.reserve()
+	if delay == 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return 0, ctx.Err()
+	case <-timer.C:
+		return delay, nil
+	}
+}
+
+func (c *Client) rateLimitMiddleware(next RoundTrip) RoundTrip {
+	return func(req *http.Request) (*http.Response, error) {
+		info, _ := CallInfoFromContext(req.Context())
+
+		wait, err := c.limiter.Wait(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		c.metrics.RateLimitWait(info.Endpoint, wait)
+
+		return next(req)
+	}
+}
@@ -0,0 +1,68 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	t.Parallel()
+
+	t.Run("burst then throttle", func(t *testing.T) {
+		t.Parallel()
+
+		l := newRateLimiter(100, 2)
+		ctx := context.Background()
+
+		for i := 0; i < 2; i++ {
+			wait, err := l.Wait(ctx)
+			require.NoError(t, err)
+			require.Zero(t, wait)
+		}
+
+		wait, err := l.Wait(ctx)
+		require.NoError(t, err)
+		require.Greater(t, wait, time.Duration(0))
+		require.LessOrEqual(t, wait, 10*time.Millisecond)
+	})
+
+	t.Run("cancelled wait", func(t *testing.T) {
+		t.Parallel()
+
+		l := newRateLimiter(1, 1)
+
+		_, err := l.Wait(context.Background())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+		defer cancel()
+
+		_, err = l.Wait(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		t.Parallel()
+
+		for _, rps := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+			require.Nil(t, newRateLimiter(rps, 10), "rps %v", rps)
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"total":0,"offset":0,"limit":1000,"cars":[]}`))
+		}))
+		t.Cleanup(server.Close)
+
+		c := NewClient(ClientConfig{ClientID: testClientID, APIKey: testAPIKey}, WithAPIHost(server.URL), WithRateLimit(0, 1))
+		require.Nil(t, c.limiter)
+		for i := 0; i < 3; i++ {
+			_, err := c.GetCarsList(context.Background(), GetCarsListArgs{ParkID: "park-1"})
+			require.NoError(t, err)
+		}
+	})
+}
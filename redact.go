@@ -0,0 +1,133 @@
+package yandex_taxi_go
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const defaultRedactionMask = "***"
+
+// RedactionPolicy Правила маскирования секретов и персональных данных в логах
+type RedactionPolicy struct {
+	Headers []string // Заголовки, значения которых маскируются
+	// Fields Пути к полям JSON, значения которых маскируются. Путь сравнивается с концом полного пути поля,
+	// массивы не добавляют сегментов: правило "driver_license.number" маскирует
+	// driver_profiles.driver_profile.driver_license.number
+	Fields []string
+	Mask   string // Строка-замена, по умолчанию ***
+}
+
+// DefaultRedactionPolicy Маскирует ключ API, телефоны, номера водительских удостоверений, ФИО водителей,
+// в том числе в заказах, и строку поиска профилей, в которой обычно передают телефон или ФИО
+func DefaultRedactionPolicy() RedactionPolicy {
+	return RedactionPolicy{
+		Headers: []string{headerXAPIKey, "Authorization"},
+		Fields: []string{
+			"phones",
+			"phone",
+			"driver_license.number",
+			"driver_license.normalized_number",
+			"first_name",
+			"last_name",
+			"middle_name",
+			"driver_profile.name",
+			"query.text",
+		},
+		Mask: defaultRedactionMask,
+	}
+}
+
+// RedactHeaders Возвращает копию заголовков с замаскированными значениями
+func (p RedactionPolicy) RedactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	for _, name := range p.Headers {
+		if values := out.Values(name); len(values) > 0 {
+			masked := make([]string, len(values))
+			for i := range masked {
+				masked[i] = p.mask()
+			}
+			out[http.CanonicalHeaderKey(name)] = masked
+		}
+	}
+	return out
+}
+
+// RedactJSON Возвращает копию JSON-документа с замаскированными полями. Если data не является JSON,
+// возвращается только его размер, чтобы не допустить утечки данных
+func (p RedactionPolicy) RedactJSON(data []byte) []byte {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return data
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return []byte(fmt.Sprintf("<non-json body: %d bytes>", len(data)))
+	}
+
+	out, err := json.Marshal(p.redactValue(doc, ""))
+	if err != nil {
+		return []byte(fmt.Sprintf("<non-json body: %d bytes>", len(data)))
+	}
+	return out
+}
+
+func (p RedactionPolicy) redactValue(v any, path string) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for key, item := range val {
+			itemPath := key
+			if path != "" {
+				itemPath = path + "." + key
+			}
+			if p.matchField(itemPath) {
+				val[key] = p.maskValue(item)
+				continue
+			}
+			val[key] = p.redactValue(item, itemPath)
+		}
+		return val
+	case []any:
+		for i := range val {
+			val[i] = p.redactValue(val[i], path)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+func (p RedactionPolicy) maskValue(v any) any {
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		for i := range val {
+			val[i] = p.maskValue(val[i])
+		}
+		return val
+	default:
+		return p.mask()
+	}
+}
+
+func (p RedactionPolicy) matchField(path string) bool {
+	for _, field := range p.Fields {
+		if path == field || strings.HasSuffix(path, "."+field) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p RedactionPolicy) mask() string {
+	if p.Mask == "" {
+		return defaultRedactionMask
+	}
+	return p.Mask
+}
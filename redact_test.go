@@ -0,0 +1,114 @@
+package yandex_taxi_go
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+func TestRedactionPolicy_RedactJSON(t *testing.T) {
+	t.Parallel()
+
+	policy := DefaultRedactionPolicy()
+
+	t.Run("driver profile", func(t *testing.T) {
+		t.Parallel()
+
+		data := []byte(`{
+			"parks": [{"id": "park-id", "name": "Park name"}],
+			"driver_profiles": [{
+				"car": {"id": "car-id", "number": "Т8654Т99"},
+				"driver_profile": {
+					"id": "driver-id",
+					"first_name": "Ivan",
+					"last_name": "Ivanov",
+					"middle_name": null,
+					"phones": ["+79999999999", "+79999999998"],
+					"driver_license": {"number": "070236", "normalized_number": "AA00123456", "country": "rus"}
+				}
+			}]
+		}`)
+
+		got := string(policy.RedactJSON(data))
+
+		require.JSONEq(t, `{
+			"parks": [{"id": "park-id", "name": "Park name"}],
+			"driver_profiles": [{
+				"car": {"id": "car-id", "number": "Т8654Т99"},
+				"driver_profile": {
+					"id": "driver-id",
+					"first_name": "***",
+					"last_name": "***",
+					"middle_name": null,
+					"phones": ["***", "***"],
+					"driver_license": {"number": "***", "normalized_number": "***", "country": "rus"}
+				}
+			}]
+		}`, got)
+	})
+
+	t.Run("orders list", func(t *testing.T) {
+		t.Parallel()
+
+		data := []byte(`{
+			"cursor": "next",
+			"limit": 1,
+			"orders": [{
+				"id": "order-id",
+				"short_id": 42,
+				"status": "complete",
+				"booked_at": "2024-03-03T10:00:00+0300",
+				"category": "econom",
+				"price": "350.00",
+				"driver_profile": {"id": "driver-id", "name": "Ivanov Ivan Ivanovich"},
+				"car": {"id": "car-id", "brand_model": "Kia Rio", "callsign": "101", "license": {"number": "Т8654Т99"}},
+				"address_from": {"address": "Moscow, Tverskaya 1", "lat": 55.75, "lon": 37.61}
+			}]
+		}`)
+
+		got := string(policy.RedactJSON(data))
+
+		require.Contains(t, got, `"name":"***"`)
+		require.NotContains(t, got, "Ivanov")
+		require.Contains(t, got, `"brand_model":"Kia Rio"`)
+		require.Contains(t, got, `"category":"econom"`)
+	})
+
+	t.Run("search text", func(t *testing.T) {
+		t.Parallel()
+
+		got := string(policy.RedactJSON([]byte(`{"limit": 1000, "query": {"text": "+79999999999", "park": {"id": "park-id"}}}`)))
+
+		require.JSONEq(t, `{"limit": 1000, "query": {"text": "***", "park": {"id": "park-id"}}}`, got)
+	})
+
+	t.Run("custom policy", func(t *testing.T) {
+		t.Parallel()
+
+		custom := RedactionPolicy{Fields: []string{"query.text"}, Mask: "[hidden]"}
+		got := string(custom.RedactJSON([]byte(`{"limit": 1000, "query": {"text": "Ivanov", "park": {"id": "park-id"}}}`)))
+
+		require.JSONEq(t, `{"limit": 1000, "query": {"text": "[hidden]", "park": {"id": "park-id"}}}`, got)
+	})
+
+	t.Run("not json", func(t *testing.T) {
+		t.Parallel()
+
+		got := string(policy.RedactJSON([]byte("phone +79999999999")))
+		require.Equal(t, "<non-json body: 18 bytes>", got)
+	})
+}
+
+func TestRedactionPolicy_RedactHeaders(t *testing.T) {
+	t.Parallel()
+
+	h := http.Header{}
+	h.Set(headerXAPIKey, testAPIKey)
+	h.Set(headerXCientID, testClientID)
+
+	got := DefaultRedactionPolicy().RedactHeaders(h)
+
+	require.Equal(t, defaultRedactionMask, got.Get(headerXAPIKey))
+	require.Equal(t, testClientID, got.Get(headerXCientID))
+	require.Equal(t, testAPIKey, h.Get(headerXAPIKey))
+}
@@ -0,0 +1,179 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ErrParkNotRegistered Парк не зарегистрирован в ParkRegistry
+var ErrParkNotRegistered = errors.New("park is not registered")
+
+// ParkRegistry Набор клиентов для нескольких парков, у каждого из которых свои данные авторизации
+type ParkRegistry struct {
+	opts []ClientOption
+
+	mu      sync.RWMutex
+	clients map[ParkID]*Client
+}
+
+// NewParkRegistry Создает реестр. Параметры opts применяются ко всем клиентам реестра
+func NewParkRegistry(opts ...ClientOption) *ParkRegistry {
+	return &ParkRegistry{
+		opts:    opts,
+		clients: make(map[ParkID]*Client),
+	}
+}
+
+// Register Добавляет или заменяет клиент парка parkID. Параметры opts применяются после общих параметров реестра
+func (r *ParkRegistry) Register(parkID ParkID, cfg ClientConfig, opts ...ClientOption) {
+	clientOpts := make([]ClientOption, 0, len(r.opts)+len(opts))
+	clientOpts = append(clientOpts, r.opts...)
+	clientOpts = append(clientOpts, opts...)
+
+	c := NewClient(cfg, clientOpts...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clients[parkID] = c
+}
+
+// Remove Удаляет парк из реестра
+func (r *ParkRegistry) Remove(parkID ParkID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.clients, parkID)
+}
+
+// Client Возвращает клиент парка parkID
+func (r *ParkRegistry) Client(parkID ParkID) (*Client, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.clients[parkID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrParkNotRegistered, parkID)
+	}
+	return c, nil
+}
+
+// ParkIDs Возвращает отсортированный список зарегистрированных парков
+func (r *ParkRegistry) ParkIDs() []ParkID {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]ParkID, 0, len(r.clients))
+	for id := range r.clients {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// Park Возвращает клиент парка parkID, привязанный к этому парку
+func (r *ParkRegistry) Park(parkID ParkID) (*ParkClient, error) {
+	c, err := r.Client(parkID)
+	if err != nil {
+		return nil, err
+	}
+	return c.Park(parkID), nil
+}
+
+// FanOutResult Объединенный результат запроса ко всем паркам реестра
+type FanOutResult[T any] struct {
+	Items  []T              // Результаты всех парков, успешно выполнивших запрос, в порядке идентификаторов парков
+	Errors map[ParkID]error // Ошибки по идентификатору парка
+}
+
+// Err Возвращает объединенную ошибку по всем паркам или nil
+func (r *FanOutResult[T]) Err() error {
+	ids := make([]ParkID, 0, len(r.Errors))
+	for id := range r.Errors {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	errs := make([]error, 0, len(ids))
+	for _, id := range ids {
+		errs = append(errs, fmt.Errorf("park %s: %w", id, r.Errors[id]))
+	}
+	return errors.Join(errs...)
+}
+
+// FanOut Выполняет fn для каждого парка реестра, одновременно не более concurrency вызовов,
+// и объединяет результаты. Ошибка одного парка не прерывает запросы к остальным
+func FanOut[T any](ctx context.Context, r *ParkRegistry, concurrency int, fn func(ctx context.Context, park *ParkClient) ([]T, error)) *FanOutResult[T] {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ids := r.ParkIDs()
+	items := make([][]T, len(ids))
+	errs := make([]error, len(ids))
+
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+
+			park, err := r.Park(id)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			items[i], errs[i] = fn(ctx, park)
+		}()
+	}
+	wg.Wait()
+
+	result := &FanOutResult[T]{
+		Errors: make(map[ParkID]error),
+	}
+	for i, id := range ids {
+		if errs[i] != nil {
+			result.Errors[id] = errs[i]
+			continue
+		}
+		result.Items = append(result.Items, items[i]...)
+	}
+
+	return result
+}
+
+// ParkVehicle ТС с указанием парка, которому оно принадлежит
+type ParkVehicle struct {
+	ParkID ParkID
+	Vehicle
+}
+
+// ListCars Загружает полные списки автомобилей всех парков реестра
+func (r *ParkRegistry) ListCars(ctx context.Context, concurrency int, opts ...CallOption) *FanOutResult[ParkVehicle] {
+	return FanOut(ctx, r, concurrency, func(ctx context.Context, park *ParkClient) ([]ParkVehicle, error) {
+		var cars []ParkVehicle
+		for page := 0; ; page++ {
+			res, err := park.GetCarsList(ctx, ParkCarsListArgs{Page: page}, opts...)
+			if err != nil {
+				return nil, err
+			}
+			for i := range res.Cars {
+				cars = append(cars, ParkVehicle{ParkID: park.ID(), Vehicle: res.Cars[i]})
+			}
+			if len(res.Cars) == 0 || res.Offset+len(res.Cars) >= res.Total {
+				return cars, nil
+			}
+		}
+	})
+}
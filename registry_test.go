@@ -0,0 +1,130 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/sinland/yandex-taxi-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParkRegistry(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+	)
+
+	t.Run("client lookup", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewParkRegistry()
+		r.Register("park-b", ClientConfig{ClientID: "client-b", APIKey: "key-b"})
+		r.Register("park-a", ClientConfig{ClientID: "client-a", APIKey: "key-a"})
+
+		require.Equal(t, []ParkID{"park-a", "park-b"}, r.ParkIDs())
+
+		c, err := r.Client("park-a")
+		require.NoError(t, err)
+		creds, err := c.credentials.Credentials(ctx)
+		require.NoError(t, err)
+		require.Equal(t, Credentials{ClientID: "client-a", APIKey: "key-a"}, creds)
+
+		r.Remove("park-a")
+		_, err = r.Client("park-a")
+		require.True(t, errors.Is(err, ErrParkNotRegistered))
+	})
+
+	t.Run("list cars across parks", func(t *testing.T) {
+		t.Parallel()
+
+		// Первые два запроса ждут друг друга, чтобы проверка ограничения не зависела от планировщика
+		const concurrency = 2
+		var inFlight, maxInFlight atomic.Int32
+		var overlapOnce sync.Once
+		overlapped := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				m := maxInFlight.Load()
+				if n <= m || maxInFlight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			if n >= concurrency {
+				overlapOnce.Do(func() { close(overlapped) })
+			}
+			select {
+			case <-overlapped:
+			case <-r.Context().Done():
+				return
+			}
+
+			var req models.CarsListRequest
+			if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&req)) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			assert.Equal(t, "key-"+req.Query.Park.Id, r.Header.Get(headerXAPIKey))
+
+			if req.Query.Park.Id == "park-3" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"code":"forbidden","message":"Forbidden"}`))
+				return
+			}
+
+			bytes, _ := json.Marshal(GetCarsListResult{
+				Total: 2,
+				Limit: req.Limit,
+				Cars: []Vehicle{
+					{Id: CarID(req.Query.Park.Id + "-car-1")},
+					{Id: CarID(req.Query.Park.Id + "-car-2")},
+				},
+			})
+			_, _ = w.Write(bytes)
+		}))
+		defer server.Close()
+
+		r := NewParkRegistry(WithAPIHost(server.URL))
+		for _, id := range []ParkID{"park-1", "park-2", "park-3", "park-4"} {
+			r.Register(id, ClientConfig{ClientID: "client-" + string(id), APIKey: "key-" + string(id)})
+		}
+
+		result := r.ListCars(ctx, concurrency)
+
+		require.Equal(t, int32(concurrency), maxInFlight.Load())
+		require.Len(t, result.Items, 6)
+		require.Equal(t, ParkID("park-1"), result.Items[0].ParkID)
+		require.Equal(t, CarID("park-1-car-1"), result.Items[0].Id)
+		require.Equal(t, CarID("park-4-car-2"), result.Items[5].Id)
+
+		require.Len(t, result.Errors, 1)
+		var apiErr *APIError
+		require.True(t, errors.As(result.Errors["park-3"], &apiErr))
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+		require.EqualError(t, result.Err(), "park park-3: [403] Forbidden (forbidden)")
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewParkRegistry()
+		r.Register("park-1", ClientConfig{})
+
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		result := FanOut(cctx, r, 1, func(ctx context.Context, park *ParkClient) ([]string, error) {
+			return nil, ctx.Err()
+		})
+		require.Empty(t, result.Items)
+		require.True(t, errors.Is(result.Errors["park-1"], context.Canceled))
+	})
+}
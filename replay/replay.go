@@ -0,0 +1,251 @@
+// Package replay Транспорт http.RoundTripper для записи обмена с Fleet API в файл и воспроизведения
+// записи в тестах без сети. Подключается через fleet.WithHttpClient(&http.Client{Transport: ...})
+package replay
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	fleet "github.com/sinland/yandex-taxi-go"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Interaction Запрос и ответ, сохраненные в файле записи
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request Сохраненный запрос
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Body   string `json:"body,omitempty"`
+	// BodyHash SHA-256 нормализованного тела до маскирования. По нему запрос сопоставляется при воспроизведении,
+	// поэтому запросы, различающиеся только замаскированными полями, не путаются между собой
+	BodyHash string `json:"body_sha256,omitempty"`
+}
+
+// Response Сохраненный ответ
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+type cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Scrubber Маскирует секреты и персональные данные перед сохранением и сопоставлением
+type Scrubber struct {
+	Policy fleet.RedactionPolicy
+}
+
+// DefaultScrubber Маскирование по fleet.DefaultRedactionPolicy
+func DefaultScrubber() Scrubber {
+	return Scrubber{Policy: fleet.DefaultRedactionPolicy()}
+}
+
+func (s Scrubber) body(data []byte) string {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return ""
+	}
+	return string(normalizeJSON(s.Policy.RedactJSON(data)))
+}
+
+// bodyHash Возвращает SHA-256 нормализованного тела без маскирования. Для пустого тела возвращает пустую строку
+func bodyHash(data []byte) string {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(normalizeJSON(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeJSON Приводит JSON к каноническому виду: без пробелов и с отсортированными ключами
+func normalizeJSON(data []byte) []byte {
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return data
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return data
+	}
+	return out
+}
+
+func readBody(body io.ReadCloser) ([]byte, error) {
+	if body == nil {
+		return nil, nil
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// Recorder Транспорт, который выполняет запросы через base и запоминает обмен.
+// Запись сохраняется в файл вызовом Save
+type Recorder struct {
+	path     string
+	base     http.RoundTripper
+	scrubber Scrubber
+
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+// NewRecorder Создает транспорт записи в файл path. Если base равен nil, используется http.DefaultTransport
+func NewRecorder(path string, base http.RoundTripper, scrubber Scrubber) *Recorder {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Recorder{
+		path:     path,
+		base:     base,
+		scrubber: scrubber,
+	}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(reqBody))
+
+	res, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := readBody(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	header := res.Header.Clone()
+	header.Del("Set-Cookie")
+	header.Del("Date")
+	header.Del("Content-Length")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: Request{
+			Method:   req.Method,
+			Path:     req.URL.Path,
+			Body:     r.scrubber.body(reqBody),
+			BodyHash: bodyHash(reqBody),
+		},
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Header:     header,
+			Body:       r.scrubber.body(resBody),
+		},
+	})
+
+	return res, nil
+}
+
+// Save Сохраняет записанный обмен в файл
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(cassette{Interactions: r.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, data, 0o644)
+}
+
+// Replayer Транспорт, который отвечает на запросы из файла записи без обращения к сети.
+// Запрос сопоставляется с записью по методу, пути и хешу нормализованного тела до маскирования; записи без
+// хеша сопоставляются по замаскированному телу. Каждая запись используется один раз в порядке следования в файле
+type Replayer struct {
+	path     string
+	scrubber Scrubber
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer Загружает запись из файла path. scrubber должен совпадать с использованным при записи
+func NewReplayer(path string, scrubber Scrubber) (*Replayer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c cassette
+	if err = json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parse recording %s: %w", path, err)
+	}
+
+	return &Replayer{
+		path:         path,
+		scrubber:     scrubber,
+		interactions: c.Interactions,
+		used:         make([]bool, len(c.Interactions)),
+	}, nil
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	body, hash := r.scrubber.body(reqBody), bodyHash(reqBody)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, in := range r.interactions {
+		if r.used[i] || in.Request.Method != req.Method || in.Request.Path != req.URL.Path {
+			continue
+		}
+		if in.Request.BodyHash != "" && in.Request.BodyHash != hash || in.Request.BodyHash == "" && in.Request.Body != body {
+			continue
+		}
+		r.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("replay: no recording in %s matches %s %s with body %s", r.path, req.Method, req.URL.Path, body)
+}
+
+// Unused Возвращает записи, которые ни разу не были воспроизведены
+func (r *Replayer) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, in := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, in)
+		}
+	}
+	return unused
+}
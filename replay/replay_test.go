@@ -0,0 +1,172 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	fleet "github.com/sinland/yandex-taxi-go"
+	"github.com/sinland/yandex-taxi-go/fleettest"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "drivers.json")
+
+	server := fleettest.NewServer()
+	defer server.Close()
+
+	server.AddPark(fleettest.Park{ID: "park-1", ClientID: "client-1", APIKey: "secret-key"})
+	require.NoError(t, server.PutDriver("park-1", fleet.DriverProfile{
+		Profile: &fleet.DriverProfileData{
+			Id:            "driver-1",
+			LastName:      "Ivanov",
+			Phones:        []string{"+79990001122"},
+			WorkStatus:    "working",
+			DriverLicense: fleet.DriverLicense{Number: "7701123456"},
+		},
+	}))
+
+	recorder := NewRecorder(path, nil, DefaultScrubber())
+	live := fleet.NewClient(fleet.ClientConfig{ClientID: "client-1", APIKey: "secret-key"},
+		fleet.WithAPIHost(server.URL()),
+		fleet.WithHttpClient(&http.Client{Transport: recorder}),
+	)
+
+	recorded, err := live.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{ParkID: "park-1", Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, "Ivanov", recorded.DriverProfiles[0].Profile.LastName)
+
+	_, err = live.GetCarsList(ctx, fleet.GetCarsListArgs{ParkID: "park-2"})
+	require.Error(t, err)
+
+	require.NoError(t, recorder.Save())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "secret-key")
+	require.NotContains(t, string(data), "+79990001122")
+	require.NotContains(t, string(data), "Ivanov")
+	require.NotContains(t, string(data), "7701123456")
+
+	replayer, err := NewReplayer(path, DefaultScrubber())
+	require.NoError(t, err)
+
+	offline := fleet.NewClient(fleet.ClientConfig{ClientID: "client-1", APIKey: "other-key"},
+		fleet.WithAPIHost("http://fleet.invalid"),
+		fleet.WithHttpClient(&http.Client{Transport: replayer}),
+	)
+
+	replayed, err := offline.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{ParkID: "park-1", Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, recorded.Total, replayed.Total)
+	require.Equal(t, fleet.DriverID("driver-1"), replayed.DriverProfiles[0].Profile.Id)
+	require.Equal(t, "***", replayed.DriverProfiles[0].Profile.LastName)
+
+	_, err = offline.GetCarsList(ctx, fleet.GetCarsListArgs{ParkID: "park-2"})
+	require.EqualError(t, err, "[404] park not found (park_not_found)")
+
+	require.Empty(t, replayer.Unused())
+
+	_, err = offline.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{ParkID: "park-1", Limit: 10})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "replay: no recording")
+
+	_, err = offline.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{ParkID: "park-1", Limit: 20})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `"limit":20`)
+}
+
+func TestReplayer_MatchesUnredactedBody(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "search.json")
+
+	// Сервер отвечает числом профилей, зависящим от текста поиска
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query struct {
+				Text string `json:"text"`
+			} `json:"query"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"total":%d,"offset":0,"limit":10,"driver_profiles":[]}`, len(req.Query.Text))
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder(path, nil, DefaultScrubber())
+	live := fleet.NewClient(fleet.ClientConfig{ClientID: "client-1", APIKey: "secret-key"},
+		fleet.WithAPIHost(server.URL),
+		fleet.WithHttpClient(&http.Client{Transport: recorder}),
+	)
+	for _, text := range []string{"Ivanov", "Petrovskiy"} {
+		_, err := live.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{ParkID: "park-1", Limit: 10, QueryText: text})
+		require.NoError(t, err)
+	}
+	require.NoError(t, recorder.Save())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "Ivanov")
+	require.NotContains(t, string(data), "Petrovskiy")
+
+	replayer, err := NewReplayer(path, DefaultScrubber())
+	require.NoError(t, err)
+	offline := fleet.NewClient(fleet.ClientConfig{ClientID: "client-1", APIKey: "other-key"},
+		fleet.WithAPIHost("http://fleet.invalid"),
+		fleet.WithHttpClient(&http.Client{Transport: replayer}),
+	)
+
+	// Запросы воспроизводятся в обратном порядке и совпадают только со своими записями
+	res, err := offline.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{ParkID: "park-1", Limit: 10, QueryText: "Petrovskiy"})
+	require.NoError(t, err)
+	require.Equal(t, len("Petrovskiy"), res.Total)
+
+	_, err = offline.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{ParkID: "park-1", Limit: 10, QueryText: "Sidorov"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "replay: no recording")
+
+	res, err = offline.GetDriverProfiles(ctx, fleet.GetDriverProfilesArgs{ParkID: "park-1", Limit: 10, QueryText: "Ivanov"})
+	require.NoError(t, err)
+	require.Equal(t, len("Ivanov"), res.Total)
+	require.Empty(t, replayer.Unused())
+}
+
+func TestReplayer_LegacyRecordingWithoutHash(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "legacy.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"interactions":[{
+		"request":{"method":"POST","path":"/v1/parks/driver-profiles/list","body":"{\"query\":{\"text\":\"***\"}}"},
+		"response":{"status_code":200,"body":"{\"total\":1}"}
+	}]}`), 0o644))
+
+	replayer, err := NewReplayer(path, DefaultScrubber())
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, "http://fleet.invalid/v1/parks/driver-profiles/list", strings.NewReader(`{"query":{"text":"Ivanov"}}`))
+	require.NoError(t, err)
+	res, err := replayer.RoundTrip(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestNormalizeJSON(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, `{"a":1,"b":{"c":[1,2]}}`, string(normalizeJSON([]byte(`{ "b": {"c": [1, 2]}, "a": 1 }`))))
+	require.Equal(t, "not json", string(normalizeJSON([]byte("not json"))))
+}
@@ -0,0 +1,182 @@
+package yandex_taxi_go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/sinland/yandex-taxi-go/internal/models"
+	"io"
+	"net/http"
+)
+
+// Пути методов API. Используются в CallInfo.Endpoint, метриках и настройках кэша
+const (
+	EndpointCarsList           = "/v1/parks/cars/list"
+	EndpointDriverProfilesList = "/v1/parks/driver-profiles/list"
+	EndpointOrdersList         = "/v1/parks/orders/list"
+	EndpointParkTransactions   = "/v2/parks/transactions/list"
+	EndpointDriverTransactions = "/v2/parks/driver-profiles/transactions/list"
+)
+
+// RoundTrip Выполнение одного HTTP-запроса к API
+type RoundTrip func(req *http.Request) (*http.Response, error)
+
+// Middleware Обертка над RoundTrip. Позволяет подключить авторизацию, логирование, повторы, метрики
+// и трассировку один раз для всех методов клиента
+type Middleware func(next RoundTrip) RoundTrip
+
+// CallInfo Сведения о вызываемом методе API, доступные в middleware через CallInfoFromContext
+type CallInfo struct {
+	Endpoint string // Путь метода API, например /v1/parks/cars/list
+	ParkID   ParkID // Идентификатор партнёра, для которого выполняется запрос
+	Offset   int    // Смещение запрашиваемой страницы
+}
+
+type callInfoKey struct{}
+
+// CallInfoFromContext Возвращает сведения о вызываемом методе API из контекста запроса
+func CallInfoFromContext(ctx context.Context) (CallInfo, bool) {
+	info, ok := ctx.Value(callInfoKey{}).(CallInfo)
+	return info, ok
+}
+
+// APIError Ошибка, которую вернул API
+type APIError struct {
+	StatusCode int    // HTTP-код ответа
+	Code       string // Код ошибки
+	Message    string // Описание ошибки
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("[%d] %s (%s)", e.StatusCode, e.Message, e.Code)
+}
+
+func chainMiddlewares(rt RoundTrip, middlewares []Middleware) RoundTrip {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		rt = middlewares[i](rt)
+	}
+	return rt
+}
+
+// pipeline Полная цепочка middleware: пользовательские, затем авторизация, повторы, ограничение
+// частоты, метрики и логирование каждой попытки. Повтор после обновления авторизации выполняется
+// ниже пользовательских middleware, поэтому они видят один вызов
+func (c *Client) pipeline() []Middleware {
+	middlewares := make([]Middleware, 0, len(c.middlewares)+5)
+	middlewares = append(middlewares, c.middlewares...)
+	middlewares = append(middlewares, c.credentialsMiddleware)
+	if c.retry != nil && c.retry.MaxAttempts > 1 {
+		middlewares = append(middlewares, c.retryMiddleware)
+	}
+	if c.limiter != nil {
+		middlewares = append(middlewares, c.rateLimitMiddleware)
+	}
+	middlewares = append(middlewares, c.metricsMiddleware, c.loggingMiddleware)
+	return middlewares
+}
+
+// do Общий конвейер выполнения запроса: сериализует reqData, проводит запрос через цепочку middleware,
+// проверяет статус ответа и декодирует тело в resData. Если включен кэш, запрос выполняется через него
+func (c *Client) do(ctx context.Context, info CallInfo, reqData any, resData any, opts []CallOption) (ResponseMeta, error) {
+	if c.cache != nil && c.cache.ttl(info.Endpoint) > 0 {
+		return c.cached(ctx, info, reqData, resData, opts)
+	}
+	if c.flights != nil {
+		body, shared, err := c.fetchBody(ctx, info, reqData, opts)
+		if err != nil {
+			return ResponseMeta{}, err
+		}
+		return ResponseMeta{Shared: shared}, json.Unmarshal(body, resData)
+	}
+	return ResponseMeta{}, c.fetch(ctx, info, reqData, resData, opts)
+}
+
+// fetch Выполняет запрос и декодирует тело ответа в resData
+func (c *Client) fetch(ctx context.Context, info CallInfo, reqData any, resData any, opts []CallOption) error {
+	res, err := c.send(ctx, info, reqData, opts)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return json.NewDecoder(res.Body).Decode(resData)
+}
+
+// send Выполняет запрос и возвращает успешный ответ. Тело ответа должен закрыть вызывающий
+func (c *Client) send(ctx context.Context, info CallInfo, reqData any, opts []CallOption) (*http.Response, error) {
+	callOpts := c.callOptions(opts)
+
+	body, err := json.Marshal(reqData)
+	if err != nil {
+		return nil, err
+	}
+
+	cancel := context.CancelFunc(func() {})
+	if callOpts.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, callOpts.timeout)
+	}
+
+	ctx = context.WithValue(ctx, callInfoKey{}, info)
+
+	req, err := c.newRequest(ctx, info, body, callOpts)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	res, err := c.roundTrip(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer cancel()
+		defer res.Body.Close()
+		return nil, decodeAPIError(res)
+	}
+
+	res.Body = &cancelBody{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// newRequest Запрос к API без данных авторизации, их подставляет credentialsMiddleware
+func (c *Client) newRequest(ctx context.Context, info CallInfo, body []byte, callOpts callOptions) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiHost+info.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range callOpts.headers {
+		req.Header[key] = values
+	}
+	if callOpts.idempotencyToken != "" {
+		req.Header.Set(headerXIdempotencyToken, callOpts.idempotencyToken)
+	}
+	if callOpts.language != "" {
+		req.Header.Set(headerAcceptLanguage, callOpts.language)
+	}
+	req.Header.Set(headerContentType, contentTypeJson)
+
+	return req, nil
+}
+
+// cancelBody Освобождает контекст вызова после закрытия тела ответа
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+func decodeAPIError(res *http.Response) error {
+	resData := models.ErrorResponse{}
+	if err := json.NewDecoder(res.Body).Decode(&resData); err != nil {
+		return &APIError{StatusCode: res.StatusCode, Message: http.StatusText(res.StatusCode)}
+	}
+	return &APIError{StatusCode: res.StatusCode, Code: resData.Code, Message: resData.Message}
+}
@@ -0,0 +1,120 @@
+package yandex_taxi_go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Middleware(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+	)
+
+	t.Run("chain order and call info", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "outer,inner", r.Header.Get("X-Trace"))
+			require.Equal(t, defaultLanguage, r.Header.Get(headerAcceptLanguage))
+
+			bytes, _ := json.Marshal(GetCarsListResult{})
+			_, err := w.Write(bytes)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		var calls []string
+		tag := func(name string) Middleware {
+			return func(next RoundTrip) RoundTrip {
+				return func(req *http.Request) (*http.Response, error) {
+					info, ok := CallInfoFromContext(req.Context())
+					require.True(t, ok)
+					calls = append(calls, name+":"+info.Endpoint)
+
+					if v := req.Header.Get("X-Trace"); v != "" {
+						req.Header.Set("X-Trace", v+","+name)
+					} else {
+						req.Header.Set("X-Trace", name)
+					}
+					return next(req)
+				}
+			}
+		}
+
+		c := NewClient(ClientConfig{
+			ClientID: testClientID,
+			APIKey:   testAPIKey,
+		}, WithAPIHost(server.URL), WithMiddleware(tag("outer")), WithMiddleware(tag("inner")))
+
+		_, err := c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-id"})
+		require.NoError(t, err)
+
+		_, err = c.GetDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id"})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{
+			"outer:" + EndpointCarsList,
+			"inner:" + EndpointCarsList,
+			"outer:" + EndpointDriverProfilesList,
+			"inner:" + EndpointDriverProfilesList,
+		}, calls)
+	})
+
+	t.Run("short circuit", func(t *testing.T) {
+		t.Parallel()
+
+		stub := func(next RoundTrip) RoundTrip {
+			return func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusTooManyRequests,
+					Body:       io.NopCloser(bytes.NewBufferString(`{"code":"429","message":"Too many requests"}`)),
+				}, nil
+			}
+		}
+
+		c := NewClient(ClientConfig{
+			ClientID: testClientID,
+			APIKey:   testAPIKey,
+		}, WithAPIHost("http://127.0.0.1:0"), WithMiddleware(stub))
+
+		got, err := c.GetCarsList(ctx, GetCarsListArgs{ParkID: "park-id"})
+		require.Nil(t, got)
+
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr))
+		require.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+		require.Equal(t, "429", apiErr.Code)
+		require.Equal(t, "[429] Too many requests (429)", err.Error())
+	})
+
+	t.Run("non json error body", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			_, err := w.Write([]byte("<html>bad gateway</html>"))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		c := NewClient(ClientConfig{
+			ClientID: testClientID,
+			APIKey:   testAPIKey,
+		}, WithAPIHost(server.URL))
+
+		_, err := c.GetDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id"})
+
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr))
+		require.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	})
+}
@@ -0,0 +1,98 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy Правила повтора запросов при ошибках сети, ответах 429 и 5xx
+type RetryPolicy struct {
+	MaxAttempts int           // Общее число попыток, включая первую
+	BaseDelay   time.Duration // Задержка перед первым повтором, далее удваивается
+	MaxDelay    time.Duration // Максимальная задержка между попытками, в том числе заданная заголовком Retry-After
+}
+
+// WithRetry Включает повтор запросов согласно policy
+func WithRetry(policy RetryPolicy) func(client *Client) {
+	return func(s *Client) {
+		s.retry = &policy
+	}
+}
+
+func (p RetryPolicy) delay(attempt int, res *http.Response) time.Duration {
+	d := p.BaseDelay << (attempt - 1)
+	if res != nil {
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			d = time.Duration(seconds) * time.Second
+		}
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+func retryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// retryMiddleware Повторяет запрос при временных ошибках. Тело запроса пересоздается через GetBody
+func (c *Client) retryMiddleware(next RoundTrip) RoundTrip {
+	return func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+		info, _ := CallInfoFromContext(ctx)
+
+		for attempt := 1; ; attempt++ {
+			attemptReq := req
+			if attempt > 1 {
+				attemptReq = req.Clone(ctx)
+				if req.GetBody != nil {
+					body, err := req.GetBody()
+					if err != nil {
+						return nil, err
+					}
+					attemptReq.Body = body
+				}
+			}
+
+			res, err := next(attemptReq)
+
+			var statusClass string
+			switch {
+			case err != nil:
+				if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+					return nil, err
+				}
+				statusClass = StatusClassError
+			case retryableStatus(res.StatusCode):
+				statusClass = StatusClass(res.StatusCode)
+			default:
+				return res, nil
+			}
+
+			if attempt >= c.retry.MaxAttempts || (req.Body != nil && req.GetBody == nil) {
+				return res, err
+			}
+
+			delay := c.retry.delay(attempt, res)
+			if res != nil {
+				_, _ = io.Copy(io.Discard, res.Body)
+				_ = res.Body.Close()
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+
+			c.metrics.Retry(info.Endpoint, statusClass)
+		}
+	}
+}
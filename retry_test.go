@@ -0,0 +1,158 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/sinland/yandex-taxi-go/internal/models"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type recordedMetrics struct {
+	mu       sync.Mutex
+	started  int
+	finished map[string]int
+	retries  map[string]int
+	waits    []time.Duration
+}
+
+func newRecordedMetrics() *recordedMetrics {
+	return &recordedMetrics{
+		finished: make(map[string]int),
+		retries:  make(map[string]int),
+	}
+}
+
+func (m *recordedMetrics) RequestStarted(string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.started++
+}
+
+func (m *recordedMetrics) RequestFinished(_ string, statusClass string, _ time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.finished[statusClass]++
+}
+
+func (m *recordedMetrics) Retry(_ string, statusClass string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries[statusClass]++
+}
+
+func (m *recordedMetrics) RateLimitWait(_ string, wait time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.waits = append(m.waits, wait)
+}
+
+func TestClient_Retry(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+	)
+
+	newServer := func(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req models.DriverProfilesRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "park-id", req.Query.Park.Id)
+
+			n := int(calls.Add(1))
+			if n <= len(statuses) {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(statuses[n-1])
+				_, _ = w.Write([]byte(`{"code":"error","message":"failed"}`))
+				return
+			}
+
+			bytes, _ := json.Marshal(GetDriverProfilesResult{Total: 1})
+			_, err := w.Write(bytes)
+			require.NoError(t, err)
+		}))
+		t.Cleanup(server.Close)
+		return server, &calls
+	}
+
+	t.Run("retries transient errors", func(t *testing.T) {
+		t.Parallel()
+
+		server, calls := newServer(t, http.StatusTooManyRequests, http.StatusBadGateway)
+		metrics := newRecordedMetrics()
+
+		c := NewClient(ClientConfig{
+			ClientID: testClientID,
+			APIKey:   testAPIKey,
+		}, WithAPIHost(server.URL), WithMetrics(metrics), WithRetry(RetryPolicy{
+			MaxAttempts: 3,
+			BaseDelay:   time.Millisecond,
+		}))
+
+		result, err := c.GetDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id"})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Total)
+		require.Equal(t, int32(3), calls.Load())
+
+		require.Equal(t, 3, metrics.started)
+		require.Equal(t, map[string]int{"4xx": 1, "5xx": 1, "2xx": 1}, metrics.finished)
+		require.Equal(t, map[string]int{"4xx": 1, "5xx": 1}, metrics.retries)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		t.Parallel()
+
+		server, calls := newServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+		c := NewClient(ClientConfig{
+			ClientID: testClientID,
+			APIKey:   testAPIKey,
+		}, WithAPIHost(server.URL), WithRetry(RetryPolicy{
+			MaxAttempts: 2,
+			BaseDelay:   time.Millisecond,
+		}))
+
+		_, err := c.GetDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id"})
+		require.Error(t, err)
+		require.Equal(t, "[503] failed (error)", err.Error())
+		require.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		t.Parallel()
+
+		server, calls := newServer(t, http.StatusBadRequest)
+
+		c := NewClient(ClientConfig{
+			ClientID: testClientID,
+			APIKey:   testAPIKey,
+		}, WithAPIHost(server.URL), WithRetry(RetryPolicy{
+			MaxAttempts: 3,
+			BaseDelay:   time.Millisecond,
+		}))
+
+		_, err := c.GetDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id"})
+		require.Error(t, err)
+		require.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	t.Parallel()
+
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	require.Equal(t, 100*time.Millisecond, p.delay(1, nil))
+	require.Equal(t, 400*time.Millisecond, p.delay(3, nil))
+	require.Equal(t, time.Second, p.delay(5, nil))
+
+	res := &http.Response{Header: http.Header{"Retry-After": []string{"30"}}}
+	require.Equal(t, time.Second, p.delay(1, res))
+}
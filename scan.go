@@ -0,0 +1,115 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// TotalChangedError Общее число элементов списка изменилось во время чтения страниц. Результат чтения
+// в этом случае может содержать пропуски или повторы
+type TotalChangedError struct {
+	Expected int // Значение Total первой страницы
+	Actual   int // Значение Total страницы, на которой обнаружено изменение
+	Offset   int // Смещение этой страницы
+}
+
+func (e *TotalChangedError) Error() string {
+	return fmt.Sprintf("total changed during scan: %d -> %d at offset %d", e.Expected, e.Actual, e.Offset)
+}
+
+// ScanDriverProfiles Загружает все профили водителей, начиная со смещения args.Offset, страницами
+// по args.Limit. После первой страницы остальные загружаются параллельно, не более concurrency
+// запросов одновременно; ограничение частоты запросов клиента соблюдается. Профили возвращаются
+// в порядке API. Если Total изменился во время чтения, возвращается прочитанный результат
+// вместе с ошибкой *TotalChangedError
+func (c *Client) ScanDriverProfiles(ctx context.Context, args GetDriverProfilesArgs, concurrency int, opts ...CallOption) (*GetDriverProfilesResult, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if args.Limit == 0 {
+		args.Limit = defaultPageLimit
+	}
+
+	first, err := c.GetDriverProfiles(ctx, args, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var offsets []int
+	for offset := args.Offset + args.Limit; offset < first.Total; offset += args.Limit {
+		offsets = append(offsets, offset)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([]*GetDriverProfilesResult, len(offsets))
+
+	// Первая ошибка отменяет остальные запросы; ошибки, вызванные этой отменой, не сохраняются
+	var (
+		errOnce  sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+
+	for i, offset := range offsets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				fail(ctx.Err())
+				return
+			}
+			defer func() { <-sem }()
+
+			pageArgs := args
+			pageArgs.Offset = offset
+			page, err := c.GetDriverProfiles(ctx, pageArgs, opts...)
+			if err != nil {
+				fail(err)
+				return
+			}
+			pages[i] = page
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	result := &GetDriverProfilesResult{
+		Total:          first.Total,
+		Offset:         first.Offset,
+		Limit:          first.Limit,
+		Parks:          first.Parks,
+		DriverProfiles: make([]DriverProfile, 0, max(first.Total-args.Offset, len(first.DriverProfiles))),
+		Meta:           first.Meta,
+	}
+	result.DriverProfiles = append(result.DriverProfiles, first.DriverProfiles...)
+
+	var changed *TotalChangedError
+	for i, page := range pages {
+		if changed == nil && page.Total != first.Total {
+			changed = &TotalChangedError{Expected: first.Total, Actual: page.Total, Offset: offsets[i]}
+		}
+		result.DriverProfiles = append(result.DriverProfiles, page.DriverProfiles...)
+	}
+
+	if changed != nil {
+		return result, changed
+	}
+	return result, nil
+}
@@ -0,0 +1,160 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/sinland/yandex-taxi-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type pagedServer struct {
+	url      string
+	total    atomic.Int32
+	inFlight atomic.Int32
+	peak     atomic.Int32
+	failAt   int
+
+	overlap     int32 // Сколько запросов страниц после первой ждут друг друга перед ответом
+	overlapOnce sync.Once
+	overlapped  chan struct{}
+}
+
+// newPagedServer Сервер со списком из total профилей. Запрос со смещением failAt завершается ошибкой 400
+func newPagedServer(t *testing.T, total int, failAt int) *pagedServer {
+	s := &pagedServer{failAt: failAt, overlapped: make(chan struct{})}
+	s.total.Store(int32(total))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req models.DriverProfilesRequest
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&req)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		n := s.inFlight.Add(1)
+		defer s.inFlight.Add(-1)
+		for {
+			peak := s.peak.Load()
+			if n <= peak || s.peak.CompareAndSwap(peak, n) {
+				break
+			}
+		}
+
+		// Первая страница запрашивается одна, параллельно загружаются только следующие
+		if s.overlap > 0 && req.Offset > 0 {
+			if n >= s.overlap {
+				s.overlapOnce.Do(func() { close(s.overlapped) })
+			}
+			select {
+			case <-s.overlapped:
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if req.Offset == s.failAt {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		total := int(s.total.Load())
+		res := GetDriverProfilesResult{Total: total, Offset: req.Offset, Limit: req.Limit}
+		for i := req.Offset; i < total && i < req.Offset+req.Limit; i++ {
+			res.DriverProfiles = append(res.DriverProfiles, DriverProfile{
+				Profile: &DriverProfileData{Id: DriverID(fmt.Sprintf("driver-%d", i))},
+			})
+		}
+		bytes, _ := json.Marshal(res)
+		_, _ = w.Write(bytes)
+	}))
+	t.Cleanup(server.Close)
+
+	s.url = server.URL
+	return s
+}
+
+func driverIDs(profiles []DriverProfile) []DriverID {
+	ids := make([]DriverID, 0, len(profiles))
+	for _, p := range profiles {
+		ids = append(ids, p.Profile.Id)
+	}
+	return ids
+}
+
+func TestClient_ScanDriverProfiles(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("pages are fetched concurrently and returned in order", func(t *testing.T) {
+		t.Parallel()
+
+		s := newPagedServer(t, 25, -1)
+		s.overlap = 3
+		metrics := newRecordedMetrics()
+		c := NewClient(ClientConfig{ClientID: testClientID, APIKey: testAPIKey},
+			WithAPIHost(s.url), WithRateLimit(1000, 10), WithMetrics(metrics))
+
+		res, err := c.ScanDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id", Limit: 3}, 3)
+		require.NoError(t, err)
+		require.Equal(t, 25, res.Total)
+
+		var want []DriverID
+		for i := 0; i < 25; i++ {
+			want = append(want, DriverID(fmt.Sprintf("driver-%d", i)))
+		}
+		require.Equal(t, want, driverIDs(res.DriverProfiles))
+
+		require.Equal(t, int32(3), s.peak.Load())
+		require.Equal(t, 9, metrics.started)
+		require.Len(t, metrics.waits, 9)
+	})
+
+	t.Run("start offset", func(t *testing.T) {
+		t.Parallel()
+
+		s := newPagedServer(t, 10, -1)
+		c := NewClient(ClientConfig{ClientID: testClientID, APIKey: testAPIKey}, WithAPIHost(s.url))
+
+		res, err := c.ScanDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id", Offset: 4, Limit: 4}, 2)
+		require.NoError(t, err)
+		require.Equal(t, []DriverID{"driver-4", "driver-5", "driver-6", "driver-7", "driver-8", "driver-9"}, driverIDs(res.DriverProfiles))
+	})
+
+	t.Run("total change is reported", func(t *testing.T) {
+		t.Parallel()
+
+		s := newPagedServer(t, 10, -1)
+		c := NewClient(ClientConfig{ClientID: testClientID, APIKey: testAPIKey}, WithAPIHost(s.url),
+			WithMiddleware(func(next RoundTrip) RoundTrip {
+				return func(req *http.Request) (*http.Response, error) {
+					res, err := next(req)
+					s.total.Store(12)
+					return res, err
+				}
+			}))
+
+		res, err := c.ScanDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id", Limit: 5}, 2)
+		require.Equal(t, &TotalChangedError{Expected: 10, Actual: 12, Offset: 5}, err)
+		require.EqualError(t, err, "total changed during scan: 10 -> 12 at offset 5")
+		require.Len(t, res.DriverProfiles, 10)
+	})
+
+	t.Run("page error cancels scan", func(t *testing.T) {
+		t.Parallel()
+
+		s := newPagedServer(t, 100, 20)
+		c := NewClient(ClientConfig{ClientID: testClientID, APIKey: testAPIKey}, WithAPIHost(s.url))
+
+		res, err := c.ScanDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id", Limit: 10}, 2)
+		require.Nil(t, res)
+		require.EqualError(t, err, "[400] Bad Request ()")
+	})
+}
@@ -0,0 +1,173 @@
+package yandex_taxi_go
+
+import "context"
+
+const defaultSnapshotPasses = 3
+
+// SnapshotOptions Параметры SnapshotDriverProfiles
+type SnapshotOptions struct {
+	MaxPasses int // Наибольшее число полных проходов по списку. По умолчанию 3
+}
+
+// snapshotSortOrder Порядок, в котором SnapshotDriverProfiles читает список: новые профили
+// добавляются в конец, поэтому не сдвигают уже прочитанные страницы
+var snapshotSortOrder = []DriverProfileSortOrder{
+	{Field: "driver_profile.created_date", Direction: "asc"},
+}
+
+// DriverProfilesSnapshot Полный список профилей водителей, собранный постраничным чтением
+type DriverProfilesSnapshot struct {
+	DriverProfiles []DriverProfile     // Профили без повторов в порядке первого появления
+	Parks          []DriverProfilePark // Список партнеров
+	Total          int                 // Total последнего прохода
+	Passes         int                 // Число выполненных проходов
+	Requeried      int                 // Число страниц, запрошенных повторно из-за сдвига строк
+	Duplicates     int                 // Число отброшенных повторов
+	Complete       bool                // Последний проход прочитал список без сдвигов на границах страниц
+}
+
+// SnapshotDriverProfiles Собирает полный список профилей водителей, защищаясь от сдвигов offset-пагинации.
+// Список читается в порядке создания профилей (args.SortOrder заменяется), каждая следующая
+// страница запрашивается с последней строкой предыдущей. Если эта строка оказалась на другом
+// месте, значит перед ней профили удалили или вставили, и проход считается нестабильным: после
+// его окончания выполняется следующий, но не больше snap.MaxPasses. Если при этом изменился Total,
+// окно перед текущей страницей запрашивается повторно, чтобы подобрать сдвинувшиеся назад строки.
+// Профили дедуплицируются по идентификатору, в результат попадают профили последнего прохода.
+// Complete гарантирует, что в снимок попал каждый профиль, существовавший на протяжении всего
+// последнего прохода, при условии, что created_date новых профилей не раньше существующих
+func (c *Client) SnapshotDriverProfiles(ctx context.Context, args GetDriverProfilesArgs, snap SnapshotOptions, opts ...CallOption) (*DriverProfilesSnapshot, error) {
+	if snap.MaxPasses < 1 {
+		snap.MaxPasses = defaultSnapshotPasses
+	}
+	if args.Limit == 0 {
+		args.Limit = defaultPageLimit
+	}
+	args.SortOrder = snapshotSortOrder
+
+	s := &snapshotBuilder{
+		client: c,
+		args:   args,
+		opts:   opts,
+		result: &DriverProfilesSnapshot{},
+	}
+
+	for s.result.Passes < snap.MaxPasses && !s.result.Complete {
+		if err := s.pass(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.result, nil
+}
+
+type snapshotBuilder struct {
+	client *Client
+	args   GetDriverProfilesArgs
+	opts   []CallOption
+	index  map[DriverID]int
+	result *DriverProfilesSnapshot
+}
+
+// pass Один проход по списку, заменяющий результат предыдущего. Проход полный, если на каждой
+// границе страниц последняя строка предыдущей страницы осталась на своем месте
+func (s *snapshotBuilder) pass(ctx context.Context) error {
+	s.result.Passes++
+	s.result.DriverProfiles = nil
+	s.index = make(map[DriverID]int)
+
+	stable := true
+	total := -1
+	var last *DriverProfile // Последняя строка предыдущей страницы
+
+	for offset := s.args.Offset; ; {
+		from, limit := offset, s.args.Limit
+		if last != nil {
+			// Страница начинается с последней строки предыдущей, чтобы проверить непрерывность.
+			// Размер страницы не может превысить ограничение API, поэтому при наибольшем размере
+			// новых строк на странице на одну меньше
+			from, limit = offset-1, min(limit+1, maxPageLimit)
+		}
+
+		page, err := s.page(ctx, from, limit)
+		if err != nil {
+			return err
+		}
+		rows := page.DriverProfiles
+		shifted := false
+		if last != nil {
+			if len(rows) > 0 && sameDriverProfile(rows[0], *last) {
+				rows = rows[1:]
+			} else {
+				shifted = true
+				stable = false
+			}
+		}
+
+		if shifted && page.Total != total {
+			// Удаления перед текущей страницей сдвигают строки назад, в уже прочитанное окно
+			shift := page.Total - total
+			if shift < 0 {
+				shift = -shift
+			}
+			for window := max(s.args.Offset, from-shift); window < from; window += s.args.Limit {
+				requeried, err := s.page(ctx, window, min(s.args.Limit, from-window))
+				if err != nil {
+					return err
+				}
+				s.result.Requeried++
+				s.add(requeried.DriverProfiles)
+			}
+		}
+		total = page.Total
+
+		s.add(rows)
+		offset = from + len(page.DriverProfiles)
+		if len(rows) == 0 || offset >= page.Total {
+			break
+		}
+		last = &page.DriverProfiles[len(page.DriverProfiles)-1]
+	}
+
+	s.result.Total = total
+	s.result.Complete = stable
+	return nil
+}
+
+// sameDriverProfile Признак того, что строки списка относятся к одному профилю
+func sameDriverProfile(a, b DriverProfile) bool {
+	return a.Profile != nil && b.Profile != nil && a.Profile.Id == b.Profile.Id
+}
+
+func (s *snapshotBuilder) page(ctx context.Context, offset, limit int) (*GetDriverProfilesResult, error) {
+	args := s.args
+	args.Offset = offset
+	args.Limit = limit
+
+	page, err := s.client.GetDriverProfiles(ctx, args, s.opts...)
+	if err != nil {
+		return nil, err
+	}
+	if page.Parks != nil {
+		s.result.Parks = page.Parks
+	}
+	return page, nil
+}
+
+// add Добавляет профили в снимок. Повтор заменяет ранее сохраненный профиль более свежими данными
+func (s *snapshotBuilder) add(profiles []DriverProfile) {
+	for _, p := range profiles {
+		if p.Profile == nil {
+			s.result.DriverProfiles = append(s.result.DriverProfiles, p)
+			continue
+		}
+
+		id := p.Profile.Id
+		if i, ok := s.index[id]; ok {
+			s.result.DriverProfiles[i] = p
+			s.result.Duplicates++
+			continue
+		}
+		s.index[id] = len(s.result.DriverProfiles)
+		s.result.DriverProfiles = append(s.result.DriverProfiles, p)
+	}
+}
@@ -0,0 +1,48 @@
+package yandex_taxi_go_test
+
+import (
+	"context"
+	"fmt"
+	fleet "github.com/sinland/yandex-taxi-go"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParkClient_SnapshotDriverProfiles(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, clock, _ := newSyncServer(t)
+
+	const drivers = 1500
+	for i := 0; i < drivers; i++ {
+		require.NoError(t, s.PutDriver("park-1", testDriver(fleet.DriverID(fmt.Sprintf("driver-%04d", i)), "+79990000001")))
+		clock.Advance(time.Second)
+	}
+
+	// После первой страницы удаляется профиль из уже прочитанного окна, и строки следующей страницы сдвигаются
+	var requests atomic.Int32
+	c := fleet.NewClient(fleet.ClientConfig{ClientID: "client", APIKey: "key"}, fleet.WithAPIHost(s.URL()),
+		fleet.WithMiddleware(func(next fleet.RoundTrip) fleet.RoundTrip {
+			return func(req *http.Request) (*http.Response, error) {
+				res, err := next(req)
+				if requests.Add(1) == 1 {
+					s.DeleteDriver("park-1", "driver-0000")
+				}
+				return res, err
+			}
+		}))
+
+	snap, err := c.Park("park-1").SnapshotDriverProfiles(ctx, fleet.ParkDriverProfilesArgs{}, fleet.SnapshotOptions{})
+	require.NoError(t, err)
+	require.True(t, snap.Complete)
+	require.Equal(t, 2, snap.Passes)
+	require.Equal(t, 1, snap.Requeried)
+	require.Equal(t, drivers-1, snap.Total)
+	require.Len(t, snap.DriverProfiles, drivers-1)
+	require.Equal(t, fleet.DriverID("driver-0001"), snap.DriverProfiles[0].Profile.Id)
+	require.Equal(t, fleet.DriverID(fmt.Sprintf("driver-%04d", drivers-1)), snap.DriverProfiles[drivers-2].Profile.Id)
+}
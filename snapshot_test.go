@@ -0,0 +1,172 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/sinland/yandex-taxi-go/internal/models"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// newMutableListServer Сервер со списком профилей ids. Функция mutate вызывается после каждого ответа
+// с номером запроса и может изменить список
+func newMutableListServer(t *testing.T, ids []DriverID, mutate func(n int, ids []DriverID) []DriverID) *Client {
+	var (
+		mu sync.Mutex
+		n  int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req models.DriverProfilesRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		require.Equal(t, []models.DriverProfileRequestSortOrderField{{Direction: "asc", Field: "driver_profile.created_date"}}, req.SortOrder)
+
+		res := GetDriverProfilesResult{Total: len(ids), Offset: req.Offset, Limit: req.Limit}
+		for i := req.Offset; i < len(ids) && i < req.Offset+req.Limit; i++ {
+			res.DriverProfiles = append(res.DriverProfiles, DriverProfile{Profile: &DriverProfileData{Id: ids[i]}})
+		}
+		bytes, _ := json.Marshal(res)
+		_, _ = w.Write(bytes)
+
+		n++
+		if mutate != nil {
+			ids = mutate(n, ids)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return NewClient(ClientConfig{ClientID: testClientID, APIKey: testAPIKey}, WithAPIHost(server.URL))
+}
+
+func makeDriverIDs(n int) []DriverID {
+	ids := make([]DriverID, 0, n)
+	for i := 0; i < n; i++ {
+		ids = append(ids, DriverID(fmt.Sprintf("driver-%d", i)))
+	}
+	return ids
+}
+
+func TestClient_SnapshotDriverProfiles(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	args := GetDriverProfilesArgs{ParkID: "park-id", Limit: 3}
+
+	t.Run("stable list", func(t *testing.T) {
+		t.Parallel()
+
+		c := newMutableListServer(t, makeDriverIDs(10), nil)
+
+		snap, err := c.SnapshotDriverProfiles(ctx, args, SnapshotOptions{})
+		require.NoError(t, err)
+		require.True(t, snap.Complete)
+		require.Equal(t, 1, snap.Passes)
+		require.Equal(t, 10, snap.Total)
+		require.Zero(t, snap.Duplicates)
+		require.Equal(t, makeDriverIDs(10), driverIDs(snap.DriverProfiles))
+	})
+
+	t.Run("insert during scan", func(t *testing.T) {
+		t.Parallel()
+
+		c := newMutableListServer(t, makeDriverIDs(10), func(n int, ids []DriverID) []DriverID {
+			if n == 1 {
+				return append([]DriverID{"new"}, ids...)
+			}
+			return ids
+		})
+
+		snap, err := c.SnapshotDriverProfiles(ctx, args, SnapshotOptions{})
+		require.NoError(t, err)
+		require.True(t, snap.Complete)
+		require.Equal(t, 2, snap.Passes)
+		require.Equal(t, 11, snap.Total)
+		require.Len(t, snap.DriverProfiles, 11)
+		require.Equal(t, DriverID("new"), snap.DriverProfiles[0].Profile.Id)
+	})
+
+	t.Run("delete during scan re-queries window", func(t *testing.T) {
+		t.Parallel()
+
+		c := newMutableListServer(t, makeDriverIDs(10), func(n int, ids []DriverID) []DriverID {
+			if n == 1 {
+				return ids[1:]
+			}
+			return ids
+		})
+
+		snap, err := c.SnapshotDriverProfiles(ctx, args, SnapshotOptions{MaxPasses: 1})
+		require.NoError(t, err)
+		require.False(t, snap.Complete)
+		require.Equal(t, 1, snap.Requeried)
+		require.Contains(t, driverIDs(snap.DriverProfiles), DriverID("driver-3"))
+
+		snap, err = c.SnapshotDriverProfiles(ctx, args, SnapshotOptions{})
+		require.NoError(t, err)
+		require.True(t, snap.Complete)
+		require.Equal(t, makeDriverIDs(10)[1:], driverIDs(snap.DriverProfiles))
+	})
+
+	t.Run("delete and insert keep total", func(t *testing.T) {
+		t.Parallel()
+
+		// Total не меняется, но удаление перед границей страниц сдвигает строки
+		c := newMutableListServer(t, makeDriverIDs(10), func(n int, ids []DriverID) []DriverID {
+			if n == 1 {
+				return append(ids[1:], "new")
+			}
+			return ids
+		})
+
+		snap, err := c.SnapshotDriverProfiles(ctx, args, SnapshotOptions{MaxPasses: 1})
+		require.NoError(t, err)
+		require.False(t, snap.Complete)
+		require.Equal(t, 10, snap.Total)
+
+		snap, err = c.SnapshotDriverProfiles(ctx, args, SnapshotOptions{})
+		require.NoError(t, err)
+		require.True(t, snap.Complete)
+		require.Equal(t, append(makeDriverIDs(10)[1:], "new"), driverIDs(snap.DriverProfiles))
+	})
+
+	t.Run("append during scan", func(t *testing.T) {
+		t.Parallel()
+
+		// Новые профили попадают в конец списка и не сдвигают прочитанные страницы
+		c := newMutableListServer(t, makeDriverIDs(10), func(n int, ids []DriverID) []DriverID {
+			if n == 1 {
+				return append(ids, "new")
+			}
+			return ids
+		})
+
+		snap, err := c.SnapshotDriverProfiles(ctx, args, SnapshotOptions{})
+		require.NoError(t, err)
+		require.True(t, snap.Complete)
+		require.Equal(t, 1, snap.Passes)
+		require.Zero(t, snap.Requeried)
+		require.Equal(t, append(makeDriverIDs(10), "new"), driverIDs(snap.DriverProfiles))
+	})
+
+	t.Run("list keeps changing", func(t *testing.T) {
+		t.Parallel()
+
+		c := newMutableListServer(t, makeDriverIDs(10), func(n int, ids []DriverID) []DriverID {
+			return append([]DriverID{DriverID(fmt.Sprintf("new-%d", n))}, ids...)
+		})
+
+		snap, err := c.SnapshotDriverProfiles(ctx, args, SnapshotOptions{MaxPasses: 2})
+		require.NoError(t, err)
+		require.False(t, snap.Complete)
+		require.Equal(t, 2, snap.Passes)
+		require.Positive(t, snap.Duplicates)
+	})
+}
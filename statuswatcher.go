@@ -0,0 +1,298 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultStatusPollInterval = 10 * time.Second
+
+// statusFields Проекция профиля для StatusWatcher: идентификатор водителя и текущее состояние
+var statusFields = DriverProfileFields{
+	Account:       []string{},
+	Car:           []string{},
+	CurrentStatus: []string{"status", "status_updated_at"},
+	DriverProfile: []string{"id"},
+	Park:          []string{},
+}
+
+// StatusTransition Смена текущего состояния водителя
+type StatusTransition struct {
+	ParkID   ParkID
+	DriverID DriverID
+	From     string    // Предыдущее состояние. Пустая строка, если водитель наблюдается впервые
+	To       string    // Новое состояние, например "online", "busy", "offline"
+	At       time.Time // Время смены по данным API или время опроса, если API его не вернул
+}
+
+// StatusWatcherConfig Параметры StatusWatcher
+type StatusWatcherConfig struct {
+	ParkID      ParkID
+	Interval    time.Duration // Период опроса. По умолчанию и при отрицательном значении 10 секунд
+	PageLimit   int           // Размер страницы. По умолчанию 1000
+	EmitInitial bool          // Публиковать состояния, полученные первым опросом, как переходы из пустого состояния
+}
+
+// StatusWatcher Следит за текущими состояниями водителей парка: периодически запрашивает профили
+// с проекцией на текущее состояние, сравнивает с предыдущим снимком и публикует переходы подписчикам.
+// Первый опрос по умолчанию только запоминает исходные состояния. Водители, пропавшие из списка,
+// забываются без события
+type StatusWatcher struct {
+	client *Client
+	cfg    StatusWatcherConfig
+
+	mu          sync.Mutex
+	statuses    map[DriverID]string
+	initialized bool
+	subscribers map[int]*statusSubscriber
+	nextID      int
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+type statusSubscriber struct {
+	ch chan StatusTransition
+	fn func(StatusTransition)
+
+	mu     sync.Mutex // Удерживается на время отправки в ch, чтобы канал не закрылся во время отправки
+	done   chan struct{}
+	closed bool
+}
+
+func newStatusSubscriber(ch chan StatusTransition, fn func(StatusTransition)) *statusSubscriber {
+	return &statusSubscriber{ch: ch, fn: fn, done: make(chan struct{})}
+}
+
+// deliver Передает переход подписчику. Ожидание места в буфере канала прерывается отпиской или отменой ctx
+func (s *statusSubscriber) deliver(ctx context.Context, t StatusTransition) {
+	if s.fn != nil {
+		s.fn(t)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	select {
+	case s.ch <- t:
+	case <-s.done:
+	case <-ctx.Done():
+	}
+}
+
+// close Завершает подписку и закрывает канал. Вызывается один раз
+func (s *statusSubscriber) close() {
+	close(s.done)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	if s.ch != nil {
+		close(s.ch)
+	}
+}
+
+// NewStatusWatcher Создает наблюдателя за водителями парка cfg.ParkID. Опрос начинается вызовом Start
+func NewStatusWatcher(client *Client, cfg StatusWatcherConfig) *StatusWatcher {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultStatusPollInterval
+	}
+	if cfg.PageLimit == 0 {
+		cfg.PageLimit = defaultPageLimit
+	}
+
+	return &StatusWatcher{
+		client:      client,
+		cfg:         cfg,
+		statuses:    make(map[DriverID]string),
+		subscribers: make(map[int]*statusSubscriber),
+	}
+}
+
+// Subscribe Подписка на переходы через канал с буфером buffer. Если буфер заполнен, опрос ждет
+// читателя, поэтому канал нужно читать до отписки. Канал закрывается при отписке или остановке.
+// Возвращает функцию отписки
+func (w *StatusWatcher) Subscribe(buffer int) (<-chan StatusTransition, func()) {
+	ch := make(chan StatusTransition, buffer)
+	return ch, w.subscribe(newStatusSubscriber(ch, nil))
+}
+
+// OnTransition Подписка на переходы через функцию. Функция вызывается из горутины опроса
+// последовательно для всех переходов. Возвращает функцию отписки
+func (w *StatusWatcher) OnTransition(fn func(StatusTransition)) func() {
+	return w.subscribe(newStatusSubscriber(nil, fn))
+}
+
+func (w *StatusWatcher) subscribe(sub *statusSubscriber) func() {
+	w.mu.Lock()
+	id := w.nextID
+	w.nextID++
+	w.subscribers[id] = sub
+	w.mu.Unlock()
+
+	return func() {
+		w.mu.Lock()
+		_, ok := w.subscribers[id]
+		delete(w.subscribers, id)
+		w.mu.Unlock()
+
+		if ok {
+			sub.close()
+		}
+	}
+}
+
+// Start Запускает периодический опрос. Повторный вызов до Stop ничего не делает
+func (w *StatusWatcher) Start(ctx context.Context) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cancel != nil {
+		return
+	}
+
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.done = make(chan struct{})
+	go w.run(ctx, w.done)
+}
+
+// Stop Останавливает опрос, дожидается завершения текущей публикации и закрывает каналы подписчиков
+func (w *StatusWatcher) Stop() {
+	w.mu.Lock()
+	cancel, done := w.cancel, w.done
+	w.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+
+	w.mu.Lock()
+	subscribers := w.subscribers
+	w.subscribers = make(map[int]*statusSubscriber)
+	w.cancel = nil
+	w.mu.Unlock()
+
+	for _, sub := range subscribers {
+		sub.close()
+	}
+}
+
+func (w *StatusWatcher) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.Poll(ctx); err != nil && ctx.Err() == nil {
+			w.client.getLogger().WarnContext(ctx, "fleet status watcher poll failed",
+				"park_id", w.cfg.ParkID,
+				"error", err,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll Выполняет один опрос, публикует найденные переходы и возвращает их
+func (w *StatusWatcher) Poll(ctx context.Context) ([]StatusTransition, error) {
+	observedAt := time.Now()
+	current := make(map[DriverID]DriverProfileCurrentStatus)
+
+	args := GetDriverProfilesArgs{ParkID: w.cfg.ParkID, Limit: w.cfg.PageLimit, Fields: &statusFields}
+	for {
+		page, err := w.client.GetDriverProfiles(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range page.DriverProfiles {
+			if p.Profile != nil && p.CurrentStatus != nil {
+				current[p.Profile.Id] = *p.CurrentStatus
+			}
+		}
+
+		if len(page.DriverProfiles) == 0 || args.Offset+len(page.DriverProfiles) >= page.Total {
+			break
+		}
+		args.Offset += len(page.DriverProfiles)
+	}
+
+	w.mu.Lock()
+	emit := w.initialized || w.cfg.EmitInitial
+	var transitions []StatusTransition
+	for id, status := range current {
+		previous, known := w.statuses[id]
+		if (known && previous == status.Status) || (!known && !emit) {
+			continue
+		}
+
+		at, err := ParseTime(status.StatusUpdatedAt)
+		if err != nil {
+			at = observedAt
+		}
+		transitions = append(transitions, StatusTransition{
+			ParkID:   w.cfg.ParkID,
+			DriverID: id,
+			From:     previous,
+			To:       status.Status,
+			At:       at,
+		})
+	}
+
+	statuses := make(map[DriverID]string, len(current))
+	for id, status := range current {
+		statuses[id] = status.Status
+	}
+	w.statuses = statuses
+	w.initialized = true
+	w.mu.Unlock()
+
+	sortTransitions(transitions)
+	w.publish(ctx, transitions)
+	return transitions, nil
+}
+
+// sortTransitions Упорядочивает переходы по времени и водителю
+func sortTransitions(transitions []StatusTransition) {
+	slices.SortFunc(transitions, func(a, b StatusTransition) int {
+		if c := a.At.Compare(b.At); c != 0 {
+			return c
+		}
+		return strings.Compare(string(a.DriverID), string(b.DriverID))
+	})
+}
+
+func (w *StatusWatcher) publish(ctx context.Context, transitions []StatusTransition) {
+	if len(transitions) == 0 {
+		return
+	}
+
+	w.mu.Lock()
+	subscribers := make([]*statusSubscriber, 0, len(w.subscribers))
+	for _, sub := range w.subscribers {
+		subscribers = append(subscribers, sub)
+	}
+	w.mu.Unlock()
+
+	for _, t := range transitions {
+		for _, sub := range subscribers {
+			sub.deliver(ctx, t)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package yandex_taxi_go_test
+
+import (
+	"context"
+	fleet "github.com/sinland/yandex-taxi-go"
+	"github.com/sinland/yandex-taxi-go/fleettest"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+func putStatus(t *testing.T, s *fleettest.Server, id fleet.DriverID, status, at string) {
+	require.NoError(t, s.PutDriver("park-1", fleet.DriverProfile{
+		Car:           &fleet.Vehicle{Id: "car-1", Number: "А001АА77"},
+		CurrentStatus: &fleet.DriverProfileCurrentStatus{Status: status, StatusUpdatedAt: at},
+		Profile:       &fleet.DriverProfileData{Id: id, LastName: "Иванов"},
+	}))
+}
+
+func TestStatusWatcher_Poll(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, _, c := newSyncServer(t)
+	putStatus(t, s, "driver-1", "online", "2024-03-03T09:00:00+0000")
+	putStatus(t, s, "driver-2", "offline", "2024-03-03T09:00:00+0000")
+
+	w := fleet.NewStatusWatcher(c, fleet.StatusWatcherConfig{ParkID: "park-1"})
+
+	ch, unsubscribe := w.Subscribe(10)
+	var (
+		mu       sync.Mutex
+		received []fleet.StatusTransition
+	)
+	w.OnTransition(func(tr fleet.StatusTransition) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, tr)
+	})
+
+	transitions, err := w.Poll(ctx)
+	require.NoError(t, err)
+	require.Empty(t, transitions)
+
+	putStatus(t, s, "driver-1", "busy", "2024-03-03T10:00:00+0000")
+	putStatus(t, s, "driver-2", "online", "2024-03-03T09:30:00+0000")
+
+	transitions, err = w.Poll(ctx)
+	require.NoError(t, err)
+	want := []fleet.StatusTransition{
+		{ParkID: "park-1", DriverID: "driver-2", From: "offline", To: "online", At: time.Date(2024, time.March, 3, 9, 30, 0, 0, time.UTC)},
+		{ParkID: "park-1", DriverID: "driver-1", From: "online", To: "busy", At: time.Date(2024, time.March, 3, 10, 0, 0, 0, time.UTC)},
+	}
+	require.Len(t, transitions, 2)
+	for i := range want {
+		require.True(t, want[i].At.Equal(transitions[i].At))
+		transitions[i].At = want[i].At
+	}
+	require.Equal(t, want, transitions)
+
+	require.Equal(t, "online", (<-ch).To)
+	require.Equal(t, "busy", (<-ch).To)
+	mu.Lock()
+	require.Len(t, received, 2)
+	mu.Unlock()
+
+	unsubscribe()
+	_, ok := <-ch
+	require.False(t, ok)
+	unsubscribe()
+
+	transitions, err = w.Poll(ctx)
+	require.NoError(t, err)
+	require.Empty(t, transitions)
+}
+
+func TestStatusWatcher_EmitInitial(t *testing.T) {
+	t.Parallel()
+
+	s, _, c := newSyncServer(t)
+	putStatus(t, s, "driver-1", "online", "")
+
+	w := fleet.NewStatusWatcher(c, fleet.StatusWatcherConfig{ParkID: "park-1", EmitInitial: true})
+
+	before := time.Now()
+	transitions, err := w.Poll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, transitions, 1)
+	require.Equal(t, "", transitions[0].From)
+	require.Equal(t, "online", transitions[0].To)
+	require.False(t, transitions[0].At.Before(before))
+}
+
+func TestStatusWatcher_StartStop(t *testing.T) {
+	t.Parallel()
+
+	s, _, c := newSyncServer(t)
+	putStatus(t, s, "driver-1", "online", "")
+
+	w := fleet.NewStatusWatcher(c, fleet.StatusWatcherConfig{ParkID: "park-1", Interval: 5 * time.Millisecond})
+	ch, _ := w.Subscribe(0)
+
+	w.Start(context.Background())
+	w.Start(context.Background())
+	require.Eventually(t, func() bool { return s.Requests(fleet.EndpointDriverProfilesList) >= 1 }, time.Second, time.Millisecond)
+
+	putStatus(t, s, "driver-1", "offline", "")
+	select {
+	case tr := <-ch:
+		require.Equal(t, "offline", tr.To)
+	case <-time.After(time.Second):
+		t.Fatal("no transition")
+	}
+
+	// Переход в busy никто не читает: Stop должен прервать ожидание отправки
+	n := s.Requests(fleet.EndpointDriverProfilesList)
+	putStatus(t, s, "driver-1", "busy", "")
+	require.Eventually(t, func() bool { return s.Requests(fleet.EndpointDriverProfilesList) > n }, time.Second, time.Millisecond)
+
+	w.Stop()
+	for range ch {
+	}
+	w.Stop()
+}
+
+func TestStatusWatcher_NegativeInterval(t *testing.T) {
+	t.Parallel()
+
+	s, _, c := newSyncServer(t)
+	putStatus(t, s, "driver-1", "online", "")
+
+	w := fleet.NewStatusWatcher(c, fleet.StatusWatcherConfig{ParkID: "park-1", Interval: -time.Second})
+	w.Start(context.Background())
+	require.Eventually(t, func() bool { return s.Requests(fleet.EndpointDriverProfilesList) >= 1 }, time.Second, time.Millisecond)
+	w.Stop()
+}
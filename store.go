@@ -0,0 +1,313 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// StoreKind Вид записей в хранилище
+type StoreKind string
+
+const (
+	KindVehicle       StoreKind = "vehicles"        // Vehicle
+	KindDriverProfile StoreKind = "driver_profiles" // DriverProfile
+	KindOrder         StoreKind = "orders"          // Order
+	KindTransaction   StoreKind = "transactions"    // Transaction
+)
+
+// Store Локальное хранилище записей Fleet API. Записи хранятся в формате API и различаются видом
+// и идентификатором. Реализация должна быть безопасна для конкурентного использования.
+// Для работы с типами моделей используется Collection
+type Store interface {
+	// Upsert Добавляет запись или заменяет запись с тем же идентификатором
+	Upsert(ctx context.Context, kind StoreKind, id string, doc json.RawMessage) error
+	// Get Возвращает запись по идентификатору
+	Get(ctx context.Context, kind StoreKind, id string) (json.RawMessage, bool, error)
+	// Delete Удаляет запись. Удаление отсутствующей записи не считается ошибкой
+	Delete(ctx context.Context, kind StoreKind, id string) error
+	// Find Передает в fn записи, у которых поле field равно value, в порядке идентификаторов.
+	// field - путь через точку в формате API, например "driver_profile.work_status"
+	Find(ctx context.Context, kind StoreKind, field string, value any, fn func(id string, doc json.RawMessage) error) error
+	// Iterate Передает в fn все записи вида kind в порядке идентификаторов. Ошибка fn прерывает обход
+	Iterate(ctx context.Context, kind StoreKind, fn func(id string, doc json.RawMessage) error) error
+}
+
+// Storable Типы моделей, которые можно хранить в Store
+type Storable interface {
+	Vehicle | DriverProfile | Order | Transaction
+}
+
+// Collection Типизированный доступ к записям одного вида в Store
+type Collection[T Storable] struct {
+	store Store
+	kind  StoreKind
+}
+
+// NewCollection Создает коллекцию записей типа T в хранилище store
+func NewCollection[T Storable](store Store) *Collection[T] {
+	var zero T
+	return &Collection[T]{store: store, kind: storeKind(zero)}
+}
+
+// Kind Вид записей коллекции
+func (c *Collection[T]) Kind() StoreKind {
+	return c.kind
+}
+
+// Upsert Добавляет запись или заменяет запись с тем же идентификатором
+func (c *Collection[T]) Upsert(ctx context.Context, v T) error {
+	id, err := storeID(v)
+	if err != nil {
+		return err
+	}
+	doc, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.store.Upsert(ctx, c.kind, id, doc)
+}
+
+// Get Возвращает запись по идентификатору
+func (c *Collection[T]) Get(ctx context.Context, id string) (T, bool, error) {
+	var v T
+	doc, found, err := c.store.Get(ctx, c.kind, id)
+	if err != nil || !found {
+		return v, found, err
+	}
+	if err = json.Unmarshal(doc, &v); err != nil {
+		return v, false, fmt.Errorf("decode %s %s: %w", c.kind, id, err)
+	}
+	return v, true, nil
+}
+
+// Delete Удаляет запись по идентификатору
+func (c *Collection[T]) Delete(ctx context.Context, id string) error {
+	return c.store.Delete(ctx, c.kind, id)
+}
+
+// Find Возвращает записи, у которых поле field равно value. Если поле - массив, а value нет,
+// подходят записи, массив которых содержит value. Массивы объектов просматриваются поэлементно,
+// например "accounts.currency" у DriverProfile
+func (c *Collection[T]) Find(ctx context.Context, field string, value any) ([]T, error) {
+	var out []T
+	err := c.store.Find(ctx, c.kind, field, value, func(id string, doc json.RawMessage) error {
+		var v T
+		if err := json.Unmarshal(doc, &v); err != nil {
+			return fmt.Errorf("decode %s %s: %w", c.kind, id, err)
+		}
+		out = append(out, v)
+		return nil
+	})
+	return out, err
+}
+
+// Iterate Передает в fn все записи коллекции в порядке идентификаторов. Ошибка fn прерывает обход
+func (c *Collection[T]) Iterate(ctx context.Context, fn func(T) error) error {
+	return c.store.Iterate(ctx, c.kind, func(id string, doc json.RawMessage) error {
+		var v T
+		if err := json.Unmarshal(doc, &v); err != nil {
+			return fmt.Errorf("decode %s %s: %w", c.kind, id, err)
+		}
+		return fn(v)
+	})
+}
+
+func storeKind(v any) StoreKind {
+	switch v.(type) {
+	case Vehicle:
+		return KindVehicle
+	case DriverProfile:
+		return KindDriverProfile
+	case Order:
+		return KindOrder
+	case Transaction:
+		return KindTransaction
+	}
+	panic(fmt.Sprintf("unsupported store type %T", v))
+}
+
+// storeID Идентификатор записи модели
+func storeID(v any) (string, error) {
+	var id string
+	switch v := v.(type) {
+	case Vehicle:
+		id = string(v.Id)
+	case DriverProfile:
+		if v.Profile != nil {
+			id = string(v.Profile.Id)
+		}
+	case Order:
+		id = string(v.Id)
+	case Transaction:
+		id = string(v.Id)
+	}
+	if id == "" {
+		return "", errors.New("record id is required")
+	}
+	return id, nil
+}
+
+// fieldMatcher Проверка поля записи на равенство значению
+type fieldMatcher struct {
+	path []string
+	want any
+}
+
+func newFieldMatcher(field string, value any) (*fieldMatcher, error) {
+	if field == "" {
+		return nil, errors.New("field is required")
+	}
+
+	// Значение приводится к виду, который дает encoding/json при разборе записи
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var want any
+	if err = json.Unmarshal(data, &want); err != nil {
+		return nil, err
+	}
+	return &fieldMatcher{path: strings.Split(field, "."), want: want}, nil
+}
+
+func (m *fieldMatcher) match(doc json.RawMessage) (bool, error) {
+	var v any
+	if err := json.Unmarshal(doc, &v); err != nil {
+		return false, err
+	}
+	return matchPath(v, m.path, m.want), nil
+}
+
+func matchPath(v any, path []string, want any) bool {
+	if items, ok := v.([]any); ok {
+		if len(path) == 0 {
+			if _, wantList := want.([]any); wantList {
+				return reflect.DeepEqual(v, want)
+			}
+		}
+		return slices.ContainsFunc(items, func(item any) bool { return matchPath(item, path, want) })
+	}
+
+	if len(path) == 0 {
+		return reflect.DeepEqual(v, want)
+	}
+
+	m, ok := v.(map[string]any)
+	if !ok {
+		return false
+	}
+	next, ok := m[path[0]]
+	if !ok {
+		return false
+	}
+	return matchPath(next, path[1:], want)
+}
+
+// MemoryStore Хранилище записей в памяти
+type MemoryStore struct {
+	mu      sync.RWMutex
+	records map[StoreKind]map[string]json.RawMessage
+}
+
+// NewMemoryStore Создает хранилище записей в памяти
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[StoreKind]map[string]json.RawMessage)}
+}
+
+// Upsert Добавляет или заменяет запись
+func (s *MemoryStore) Upsert(_ context.Context, kind StoreKind, id string, doc json.RawMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.put(kind, id, doc)
+	return nil
+}
+
+func (s *MemoryStore) put(kind StoreKind, id string, doc json.RawMessage) {
+	if s.records[kind] == nil {
+		s.records[kind] = make(map[string]json.RawMessage)
+	}
+	s.records[kind][id] = slices.Clone(doc)
+}
+
+// Get Возвращает запись по идентификатору
+func (s *MemoryStore) Get(_ context.Context, kind StoreKind, id string) (json.RawMessage, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	doc, ok := s.records[kind][id]
+	return slices.Clone(doc), ok, nil
+}
+
+// Delete Удаляет запись
+func (s *MemoryStore) Delete(_ context.Context, kind StoreKind, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records[kind], id)
+	return nil
+}
+
+// Find Передает в fn записи, у которых поле field равно value
+func (s *MemoryStore) Find(ctx context.Context, kind StoreKind, field string, value any, fn func(id string, doc json.RawMessage) error) error {
+	m, err := newFieldMatcher(field, value)
+	if err != nil {
+		return err
+	}
+
+	return s.Iterate(ctx, kind, func(id string, doc json.RawMessage) error {
+		ok, err := m.match(doc)
+		if err != nil {
+			return fmt.Errorf("decode %s %s: %w", kind, id, err)
+		}
+		if !ok {
+			return nil
+		}
+		return fn(id, doc)
+	})
+}
+
+// Iterate Передает в fn все записи вида kind в порядке идентификаторов. Обход выполняется по снимку
+// записей, поэтому fn может изменять хранилище
+func (s *MemoryStore) Iterate(ctx context.Context, kind StoreKind, fn func(id string, doc json.RawMessage) error) error {
+	type record struct {
+		id  string
+		doc json.RawMessage
+	}
+
+	s.mu.RLock()
+	records := make([]record, 0, len(s.records[kind]))
+	for id, doc := range s.records[kind] {
+		records = append(records, record{id: id, doc: doc})
+	}
+	s.mu.RUnlock()
+
+	slices.SortFunc(records, func(a, b record) int { return strings.Compare(a.id, b.id) })
+	for _, r := range records {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(r.id, slices.Clone(r.doc)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Len Число записей вида kind
+func (s *MemoryStore) Len(kind StoreKind) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.records[kind])
+}
+
+var (
+	_ Store = (*MemoryStore)(nil)
+	_ Store = (*FileStore)(nil)
+)
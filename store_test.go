@@ -0,0 +1,111 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// testStoreContract Общие проверки реализаций Store
+func testStoreContract(t *testing.T, store Store) {
+	ctx := context.Background()
+	drivers := NewCollection[DriverProfile](store)
+	cars := NewCollection[Vehicle](store)
+
+	require.Equal(t, KindDriverProfile, drivers.Kind())
+
+	for _, p := range []DriverProfile{
+		{
+			Accounts: []DriverProfileAccount{{Id: "acc-1", Currency: "RUB"}},
+			Car:      &Vehicle{Id: "car-1", Category: []string{"econom", "comfort"}},
+			Profile:  &DriverProfileData{Id: "driver-2", LastName: "Петров", WorkStatus: "working"},
+		},
+		{
+			Accounts: []DriverProfileAccount{{Id: "acc-2", Currency: "KZT"}},
+			Profile:  &DriverProfileData{Id: "driver-1", LastName: "Иванов", WorkStatus: "fired"},
+		},
+		{
+			Profile: &DriverProfileData{Id: "driver-3", LastName: "Сидоров", WorkStatus: "working"},
+		},
+	} {
+		require.NoError(t, drivers.Upsert(ctx, p))
+	}
+	require.NoError(t, cars.Upsert(ctx, Vehicle{Id: "car-1", Year: 2020}))
+	require.Error(t, drivers.Upsert(ctx, DriverProfile{}))
+
+	got, found, err := drivers.Get(ctx, "driver-1")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "Иванов", got.Profile.LastName)
+
+	_, found, err = drivers.Get(ctx, "car-1")
+	require.NoError(t, err)
+	require.False(t, found, "kinds must not share ids")
+
+	working, err := drivers.Find(ctx, "driver_profile.work_status", "working")
+	require.NoError(t, err)
+	require.Len(t, working, 2)
+	require.Equal(t, DriverID("driver-2"), working[0].Profile.Id)
+
+	byCategory, err := drivers.Find(ctx, "car.category", "comfort")
+	require.NoError(t, err)
+	require.Len(t, byCategory, 1)
+
+	byCurrency, err := drivers.Find(ctx, "accounts.currency", "KZT")
+	require.NoError(t, err)
+	require.Len(t, byCurrency, 1)
+	require.Equal(t, DriverID("driver-1"), byCurrency[0].Profile.Id)
+
+	byYear, err := cars.Find(ctx, "year", 2020)
+	require.NoError(t, err)
+	require.Len(t, byYear, 1)
+
+	var ids []DriverID
+	require.NoError(t, drivers.Iterate(ctx, func(p DriverProfile) error {
+		ids = append(ids, p.Profile.Id)
+		return nil
+	}))
+	require.Equal(t, []DriverID{"driver-1", "driver-2", "driver-3"}, ids)
+
+	errStop := errors.New("stop")
+	require.ErrorIs(t, drivers.Iterate(ctx, func(DriverProfile) error { return errStop }), errStop)
+
+	require.NoError(t, drivers.Upsert(ctx, DriverProfile{Profile: &DriverProfileData{Id: "driver-1", WorkStatus: "working"}}))
+	require.NoError(t, drivers.Delete(ctx, "driver-3"))
+	require.NoError(t, drivers.Delete(ctx, "driver-unknown"))
+
+	working, err = drivers.Find(ctx, "driver_profile.work_status", "working")
+	require.NoError(t, err)
+	require.Len(t, working, 2)
+	require.Equal(t, DriverID("driver-1"), working[0].Profile.Id)
+	require.Empty(t, working[0].Profile.LastName)
+}
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	testStoreContract(t, NewMemoryStore())
+}
+
+func TestMatchPath(t *testing.T) {
+	t.Parallel()
+
+	doc := map[string]any{
+		"id":       "order-1",
+		"price":    float64(250),
+		"tags":     []any{"a", "b"},
+		"points":   []any{map[string]any{"address": "Тверская"}, map[string]any{"address": "Арбат"}},
+		"customer": map[string]any{"phone": nil},
+	}
+
+	require.True(t, matchPath(doc, []string{"id"}, "order-1"))
+	require.True(t, matchPath(doc, []string{"price"}, float64(250)))
+	require.True(t, matchPath(doc, []string{"tags"}, "b"))
+	require.True(t, matchPath(doc, []string{"tags"}, []any{"a", "b"}))
+	require.False(t, matchPath(doc, []string{"tags"}, []any{"b"}))
+	require.True(t, matchPath(doc, []string{"points", "address"}, "Арбат"))
+	require.True(t, matchPath(doc, []string{"customer", "phone"}, nil))
+	require.False(t, matchPath(doc, []string{"customer", "name"}, nil))
+	require.False(t, matchPath(doc, []string{"id", "value"}, "order-1"))
+}
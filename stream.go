@@ -0,0 +1,124 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// DriverProfilesPage Метаданные страницы профилей водителей, полученной потоковым чтением
+type DriverProfilesPage struct {
+	Total  int                 // Общее количество профилей, удовлетворяющих запросу
+	Offset int                 // Смещение относительно начала списка
+	Limit  int                 // Запрошенное число профилей
+	Count  int                 // Число профилей, переданных в обработчик
+	Parks  []DriverProfilePark // Список партнеров
+}
+
+// StreamDriverProfiles Получение списка профилей водителей без загрузки всей страницы в память.
+// Профили декодируются из тела ответа по одному и передаются в fn в порядке ответа API.
+// Если fn возвращает ошибку, чтение прекращается и ошибка возвращается без изменений.
+// Метаданные страницы возвращаются после прочтения всего ответа
+func (c *Client) StreamDriverProfiles(ctx context.Context, args GetDriverProfilesArgs, fn func(DriverProfile) error, opts ...CallOption) (*DriverProfilesPage, error) {
+	reqData := driverProfilesRequest(args)
+
+	info := CallInfo{
+		Endpoint: EndpointDriverProfilesList,
+		ParkID:   args.ParkID,
+		Offset:   reqData.Offset,
+	}
+
+	res, err := c.send(ctx, info, reqData, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	page, err := decodeDriverProfilesStream(res.Body, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _ = io.Copy(io.Discard, res.Body)
+	return page, nil
+}
+
+// decodeDriverProfilesStream Читает ответ /v1/parks/driver-profiles/list, передавая профили в fn по одному
+func decodeDriverProfilesStream(r io.Reader, fn func(DriverProfile) error) (*DriverProfilesPage, error) {
+	dec := json.NewDecoder(r)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	page := &DriverProfilesPage{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+
+		switch key {
+		case "total":
+			err = dec.Decode(&page.Total)
+		case "offset":
+			err = dec.Decode(&page.Offset)
+		case "limit":
+			err = dec.Decode(&page.Limit)
+		case "parks":
+			err = dec.Decode(&page.Parks)
+		case "driver_profiles":
+			err = decodeDriverProfilesArray(dec, page, fn)
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func decodeDriverProfilesArray(dec *json.Decoder, page *DriverProfilesPage, fn func(DriverProfile) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("driver_profiles: unexpected token %v", tok)
+	}
+
+	for dec.More() {
+		var item DriverProfile
+		if err = dec.Decode(&item); err != nil {
+			return err
+		}
+		if err = fn(item); err != nil {
+			return err
+		}
+		page.Count++
+	}
+
+	return expectDelim(dec, ']')
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("unexpected token %v, want %v", tok, want)
+	}
+	return nil
+}
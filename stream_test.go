@@ -0,0 +1,112 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/sinland/yandex-taxi-go/internal/models"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newDriverProfilesServer(t *testing.T, body string) *Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req models.DriverProfilesRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "park-id", req.Query.Park.Id)
+
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return NewClient(ClientConfig{ClientID: testClientID, APIKey: testAPIKey}, WithAPIHost(server.URL))
+}
+
+func TestClient_StreamDriverProfiles(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	profiles := make([]DriverProfile, 0, 3)
+	for i := 0; i < 3; i++ {
+		profiles = append(profiles, DriverProfile{
+			Accounts: []DriverProfileAccount{{Id: fmt.Sprintf("account-%d", i), Balance: "100.00"}},
+			Car:      &Vehicle{Id: CarID(fmt.Sprintf("car-%d", i))},
+			Profile: &DriverProfileData{
+				Id:     DriverID(fmt.Sprintf("driver-%d", i)),
+				ParkId: "park-id",
+				Phones: []string{"+79990000000"},
+			},
+		})
+	}
+	body, err := json.Marshal(GetDriverProfilesResult{
+		Total:          10,
+		Offset:         5,
+		Limit:          3,
+		Parks:          []DriverProfilePark{{Id: "park-id", City: "Москва", Name: "Park"}},
+		DriverProfiles: profiles,
+	})
+	require.NoError(t, err)
+
+	t.Run("same result as GetDriverProfiles", func(t *testing.T) {
+		t.Parallel()
+
+		c := newDriverProfilesServer(t, string(body))
+
+		want, err := c.GetDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id", Offset: 5, Limit: 3})
+		require.NoError(t, err)
+
+		var got []DriverProfile
+		page, err := c.StreamDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id", Offset: 5, Limit: 3}, func(p DriverProfile) error {
+			got = append(got, p)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, want.DriverProfiles, got)
+		require.Equal(t, &DriverProfilesPage{Total: 10, Offset: 5, Limit: 3, Count: 3, Parks: want.Parks}, page)
+	})
+
+	t.Run("fields order and unknown fields", func(t *testing.T) {
+		t.Parallel()
+
+		c := newDriverProfilesServer(t, `{"driver_profiles":[{"driver_profile":{"id":"d1"},"extra":1}],"unknown":{"a":[1,2]},"total":1,"parks":null}`)
+
+		var ids []DriverID
+		page, err := c.StreamDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id"}, func(p DriverProfile) error {
+			ids = append(ids, p.Profile.Id)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []DriverID{"d1"}, ids)
+		require.Equal(t, 1, page.Total)
+		require.Equal(t, 1, page.Count)
+	})
+
+	t.Run("callback error stops decoding", func(t *testing.T) {
+		t.Parallel()
+
+		c := newDriverProfilesServer(t, string(body))
+		errStop := errors.New("stop")
+
+		calls := 0
+		_, err := c.StreamDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id"}, func(DriverProfile) error {
+			calls++
+			return errStop
+		})
+		require.ErrorIs(t, err, errStop)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		t.Parallel()
+
+		c := newDriverProfilesServer(t, strings.TrimSuffix(string(body), "]}"))
+
+		_, err := c.StreamDriverProfiles(ctx, GetDriverProfilesArgs{ParkID: "park-id"}, func(DriverProfile) error { return nil })
+		require.Error(t, err)
+	})
+}
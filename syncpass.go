@@ -0,0 +1,124 @@
+package yandex_taxi_go
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+)
+
+// cursorSyncPass Позиция одного прохода синхронизации, которая читает окна времени курсором API.
+// Записи из окна перекрытия, уже переданные обработчику, запоминаются в Checkpoint.Seen, чтобы
+// повторное чтение окна их отбросило
+type cursorSyncPass struct {
+	store   CheckpointStore
+	key     string
+	overlap time.Duration
+	cp      Checkpoint
+}
+
+// startCursorSyncPass Загружает позицию по ключу key и возвращает начало следующего окна: отметку
+// минус overlap, а для первой синхронизации - start или, если он не задан, now минус overlap
+func startCursorSyncPass(ctx context.Context, store CheckpointStore, key string, start, now time.Time, overlap time.Duration) (*cursorSyncPass, time.Time, error) {
+	cp, found, err := store.Load(ctx, key)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("load checkpoint: %w", err)
+	}
+
+	from := cp.Watermark.Add(-overlap)
+	if !found {
+		cp.Watermark = start
+		if cp.Watermark.IsZero() {
+			cp.Watermark = now.Add(-overlap)
+		}
+		from = cp.Watermark
+	}
+
+	// Хранилище в памяти возвращает ту же карту, поэтому изменения до сохранения делаются в копии
+	cp.Seen = maps.Clone(cp.Seen)
+	if cp.Seen == nil {
+		cp.Seen = make(map[string]time.Time)
+	}
+	return &cursorSyncPass{store: store, key: key, overlap: overlap, cp: cp}, from, nil
+}
+
+// interrupted Окно и курсор обхода, который прервался на середине окна
+func (p *cursorSyncPass) interrupted() (TimeRange, string, bool) {
+	if p.cp.Window == nil || p.cp.Cursor == "" {
+		return TimeRange{}, "", false
+	}
+	return *p.cp.Window, p.cp.Cursor, true
+}
+
+// watermark Отметка, до которой данные обработаны
+func (p *cursorSyncPass) watermark() time.Time {
+	return p.cp.Watermark
+}
+
+// advance Сдвигает отметку вперед до t
+func (p *cursorSyncPass) advance(t time.Time) {
+	if t.After(p.cp.Watermark) {
+		p.cp.Watermark = t
+	}
+}
+
+// seen Признак того, что запись уже передана обработчику
+func (p *cursorSyncPass) seen(id string) bool {
+	_, ok := p.cp.Seen[id]
+	return ok
+}
+
+// mark Запоминает переданную обработчику запись и ее время
+func (p *cursorSyncPass) mark(id string, at time.Time) {
+	p.cp.Seen[id] = at
+}
+
+// commit Запоминает курсор следующей страницы окна window, пустой курсор означает, что обход окна
+// завершен. Записи раньше окна перекрытия больше не перечитываются, поэтому забываются. Сохраняет позицию
+func (p *cursorSyncPass) commit(ctx context.Context, window TimeRange, cursor string) error {
+	if cursor == "" {
+		p.cp.Cursor = ""
+		p.cp.Window = nil
+	} else {
+		p.cp.Cursor = cursor
+		p.cp.Window = &window
+	}
+
+	horizon := p.cp.Watermark.Add(-p.overlap)
+	maps.DeleteFunc(p.cp.Seen, func(_ string, at time.Time) bool { return at.Before(horizon) })
+
+	return p.save(ctx)
+}
+
+// save Сохраняет позицию
+func (p *cursorSyncPass) save(ctx context.Context) error {
+	cp := p.cp
+	cp.Seen = maps.Clone(p.cp.Seen)
+	if err := p.store.Save(ctx, p.key, cp); err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// runEvery Выполняет pass сразу и далее с периодом interval до отмены ctx. Ошибки прохода
+// записываются в журнал клиента с сообщением logMsg, следующий проход выполняется по расписанию.
+// Возвращает ошибку контекста
+func (c *Client) runEvery(ctx context.Context, interval time.Duration, logMsg string, parkID ParkID, pass func(context.Context) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := pass(ctx); err != nil && ctx.Err() == nil {
+			c.getLogger().WarnContext(ctx, logMsg,
+				"park_id", parkID,
+				"error", err,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package yandex_taxi_go
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCursorSyncPass(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryCheckpointStore()
+	now := time.Date(2024, 3, 3, 10, 0, 0, 0, time.UTC)
+
+	p, from, err := startCursorSyncPass(ctx, store, "key", time.Time{}, now, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-time.Hour), from)
+	_, _, ok := p.interrupted()
+	require.False(t, ok)
+
+	window := TimeRange{From: from, To: now}
+	p.mark("old", now.Add(-3*time.Hour))
+	p.mark("new", now.Add(-time.Minute))
+	p.advance(now)
+	p.advance(now.Add(-time.Hour))
+	require.NoError(t, p.commit(ctx, window, "cursor-1"))
+
+	// Изменения нового прохода не должны попадать в сохраненную позицию до commit
+	p.mark("unsaved", now)
+
+	p, from, err = startCursorSyncPass(ctx, store, "key", time.Time{}, now.Add(time.Hour), time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-time.Hour), from)
+	require.Equal(t, now, p.watermark())
+	require.True(t, p.seen("new"))
+	require.False(t, p.seen("old"), "records before the overlap horizon must be pruned")
+	require.False(t, p.seen("unsaved"))
+
+	resumed, cursor, ok := p.interrupted()
+	require.True(t, ok)
+	require.Equal(t, window, resumed)
+	require.Equal(t, "cursor-1", cursor)
+
+	require.NoError(t, p.commit(ctx, window, ""))
+	cp, _, err := store.Load(ctx, "key")
+	require.NoError(t, err)
+	require.Empty(t, cp.Cursor)
+	require.Nil(t, cp.Window)
+
+	t.Run("start", func(t *testing.T) {
+		start := now.Add(-24 * time.Hour)
+		_, from, err := startCursorSyncPass(ctx, store, "other", start, now, time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, start, from)
+	})
+}
+
+func TestClient_RunEvery(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var logs bytes.Buffer
+	c := NewClient(ClientConfig{ClientID: testClientID, APIKey: testAPIKey}, WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+
+	var passes atomic.Int32
+	err := c.runEvery(ctx, time.Millisecond, "test pass failed", "park-1", func(context.Context) error {
+		if passes.Add(1) == 3 {
+			cancel()
+		}
+		return errors.New("pass failed")
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, int32(3), passes.Load())
+	require.Equal(t, 2, strings.Count(logs.String(), "test pass failed"), "failure of a cancelled pass must not be logged")
+}
@@ -0,0 +1,542 @@
+package yandex_taxi_go
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWebhookQueueSize = 1000
+	defaultWebhookTimeout   = 10 * time.Second
+)
+
+// Заголовки запроса с событием
+const (
+	WebhookHeaderEvent     = "X-Fleet-Event"     // Тип события
+	WebhookHeaderDelivery  = "X-Fleet-Delivery"  // Идентификатор события, одинаковый во всех попытках доставки
+	WebhookHeaderTimestamp = "X-Fleet-Timestamp" // Время попытки в секундах Unix, входит в подпись
+	WebhookHeaderSignature = "X-Fleet-Signature" // Подпись "sha256=<hex>", см. SignWebhook
+)
+
+// ErrWebhookDispatcherStopped Рассылка остановлена
+var ErrWebhookDispatcherStopped = errors.New("webhook dispatcher is stopped")
+
+// WebhookEventType Тип события рассылки
+type WebhookEventType string
+
+const (
+	WebhookDriverCreated       WebhookEventType = "driver.created"        // DriverSync: профиль встретился впервые, data - WebhookDriverData
+	WebhookDriverUpdated       WebhookEventType = "driver.updated"        // DriverSync: профиль изменился, data - WebhookDriverData
+	WebhookDriverStatusChanged WebhookEventType = "driver.status_changed" // StatusWatcher: смена текущего состояния, data - WebhookStatusData
+	WebhookOrderSynced         WebhookEventType = "order.synced"          // OrderSync: заказ, data - Order
+	WebhookTransactionPosted   WebhookEventType = "transaction.posted"    // LedgerSync: транзакция, data - Transaction
+)
+
+// WebhookEvent Событие, которое отправляется подписчикам телом POST-запроса
+type WebhookEvent struct {
+	ID         string           `json:"id"`          // Идентификатор события. Заполняется при публикации, если пуст
+	Type       WebhookEventType `json:"type"`        // Тип события
+	OccurredAt time.Time        `json:"occurred_at"` // Время события. Заполняется при публикации, если пусто
+	ParkID     ParkID           `json:"park_id"`     // Парк, к которому относится событие
+	Data       json.RawMessage  `json:"data"`        // Данные события, формат зависит от типа
+}
+
+// WebhookDriverData Данные событий WebhookDriverCreated и WebhookDriverUpdated
+type WebhookDriverData struct {
+	DriverID  DriverID      `json:"driver_id"`
+	UpdatedAt time.Time     `json:"updated_at"`        // Время обновления профиля по данным API
+	Profile   DriverProfile `json:"profile"`           // Текущее состояние профиля
+	Changes   []FieldChange `json:"changes,omitempty"` // Измененные поля. Для WebhookDriverCreated не заполняется
+}
+
+// WebhookStatusData Данные события WebhookDriverStatusChanged
+type WebhookStatusData struct {
+	DriverID DriverID `json:"driver_id"`
+	From     string   `json:"from"` // Предыдущее состояние. Пустая строка, если водитель наблюдается впервые
+	To       string   `json:"to"`   // Новое состояние
+}
+
+// WebhookSubscriber Получатель событий
+type WebhookSubscriber struct {
+	Name   string                  // Имя подписчика для логов и dead-letter файла. По умолчанию URL
+	URL    string                  // Адрес, на который отправляются события
+	Secret string                  // Ключ подписи HMAC-SHA256
+	Events []WebhookEventType      // Типы событий подписчика. Пустой - все типы
+	Filter func(WebhookEvent) bool // Дополнительный фильтр событий. nil - все события выбранных типов
+}
+
+func (s WebhookSubscriber) accepts(event WebhookEvent) bool {
+	if len(s.Events) > 0 && !slices.Contains(s.Events, event.Type) {
+		return false
+	}
+	return s.Filter == nil || s.Filter(event)
+}
+
+// WebhookDispatcherConfig Параметры WebhookDispatcher
+type WebhookDispatcherConfig struct {
+	Subscribers    []WebhookSubscriber
+	Retry          RetryPolicy      // Повтор доставки при ошибках сети, ответах 429 и 5xx. По умолчанию 5 попыток с задержкой от секунды до минуты
+	Timeout        time.Duration    // Ограничение времени одной попытки. По умолчанию 10 секунд
+	QueueSize      int              // Размер очереди каждого подписчика. По умолчанию 1000
+	DeadLetterPath string           // Файл формата JSON Lines для недоставленных событий. Если пуст, они только пишутся в лог
+	HTTPClient     *http.Client     // По умолчанию http.DefaultClient
+	Logger         *slog.Logger     // По умолчанию slog.Default()
+	Now            func() time.Time // Источник текущего времени. По умолчанию time.Now
+}
+
+// WebhookDeadLetter Событие, которое не удалось доставить подписчику
+type WebhookDeadLetter struct {
+	Subscriber string       `json:"subscriber"`
+	URL        string       `json:"url"`
+	Event      WebhookEvent `json:"event"`
+	Attempts   int          `json:"attempts"` // Число выполненных попыток. 0, если рассылка остановлена до первой попытки
+	Error      string       `json:"error"`    // Ошибка последней попытки
+	FailedAt   time.Time    `json:"failed_at"`
+}
+
+// WebhookDispatcher Рассылка событий синхронизации и наблюдения подписчикам в виде подписанных
+// POST-запросов с JSON. У каждого подписчика своя очередь и горутина доставки, поэтому медленный
+// получатель не задерживает остальных, а события одному подписчику доставляются в порядке публикации.
+// Временные ошибки повторяются согласно cfg.Retry, ответы 4xx, кроме 429, не повторяются. Событие,
+// которое не удалось доставить, записывается в dead-letter файл.
+// Доставка выполняется не менее одного раза: получатель может отбрасывать повторы по WebhookHeaderDelivery
+type WebhookDispatcher struct {
+	cfg         WebhookDispatcherConfig
+	subscribers []*webhookSubscriber
+
+	deadMu      sync.Mutex
+	deadLetters *jsonlFile
+
+	mu       sync.RWMutex
+	started  bool
+	stopped  bool
+	cancel   context.CancelFunc
+	stopping chan struct{}
+	stopOnce sync.Once
+	workers  sync.WaitGroup
+
+	pendingMu sync.Mutex
+	pending   int
+	idle      chan struct{} // Закрыт, когда в очередях нет недоставленных событий
+}
+
+type webhookSubscriber struct {
+	WebhookSubscriber
+	queue chan WebhookEvent
+}
+
+// NewWebhookDispatcher Создает рассылку и открывает dead-letter файл. Доставка начинается вызовом Start
+func NewWebhookDispatcher(cfg WebhookDispatcherConfig) (*WebhookDispatcher, error) {
+	if cfg.Retry.MaxAttempts == 0 {
+		cfg.Retry = RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: time.Minute}
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultWebhookTimeout
+	}
+	if cfg.QueueSize == 0 {
+		cfg.QueueSize = defaultWebhookQueueSize
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	d := &WebhookDispatcher{cfg: cfg, stopping: make(chan struct{}), idle: make(chan struct{})}
+	close(d.idle)
+
+	for i, sub := range cfg.Subscribers {
+		u, err := url.Parse(sub.URL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("webhook subscriber %d: invalid url %q", i, sub.URL)
+		}
+		if sub.Name == "" {
+			sub.Name = sub.URL
+		}
+		d.subscribers = append(d.subscribers, &webhookSubscriber{
+			WebhookSubscriber: sub,
+			queue:             make(chan WebhookEvent, cfg.QueueSize),
+		})
+	}
+
+	if cfg.DeadLetterPath != "" {
+		deadLetters, err := openJSONL(cfg.DeadLetterPath, func([]byte) error { return nil })
+		if err != nil {
+			return nil, fmt.Errorf("open dead letters: %w", err)
+		}
+		d.deadLetters = deadLetters
+	}
+	return d, nil
+}
+
+// Start Запускает доставку. Повторный вызов ничего не делает
+func (d *WebhookDispatcher) Start(ctx context.Context) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.started || d.stopped {
+		return
+	}
+	d.start(ctx)
+}
+
+// start Запускает горутины доставки. Вызывается под d.mu
+func (d *WebhookDispatcher) start(ctx context.Context) {
+	ctx, d.cancel = context.WithCancel(ctx)
+	d.started = true
+
+	for _, sub := range d.subscribers {
+		d.workers.Add(1)
+		go d.run(ctx, sub)
+	}
+}
+
+// Stop Останавливает рассылку: прерывает текущие попытки, записывает недоставленные события
+// в dead-letter файл и закрывает его. Чтобы сначала доставить очереди, вызовите Flush
+func (d *WebhookDispatcher) Stop() {
+	// Publish ждет места в очереди под d.mu.RLock; закрытие stopping прерывает ожидание
+	d.stopOnce.Do(func() { close(d.stopping) })
+
+	d.mu.Lock()
+	if d.stopped {
+		d.mu.Unlock()
+		return
+	}
+	d.stopped = true
+	if !d.started {
+		// Очереди, заполненные до Start, разбираются горутинами с отмененным контекстом
+		d.start(context.Background())
+	}
+	d.cancel()
+	for _, sub := range d.subscribers {
+		close(sub.queue)
+	}
+	d.mu.Unlock()
+
+	d.workers.Wait()
+
+	d.deadMu.Lock()
+	defer d.deadMu.Unlock()
+	if d.deadLetters != nil {
+		if err := d.deadLetters.close(); err != nil {
+			d.cfg.Logger.Warn("fleet webhook dead letters close failed", "error", err)
+		}
+	}
+}
+
+// Flush Ждет, пока все опубликованные события будут доставлены или записаны в dead-letter файл
+func (d *WebhookDispatcher) Flush(ctx context.Context) error {
+	d.pendingMu.Lock()
+	idle := d.idle
+	d.pendingMu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *WebhookDispatcher) addPending() {
+	d.pendingMu.Lock()
+	defer d.pendingMu.Unlock()
+
+	if d.pending == 0 {
+		d.idle = make(chan struct{})
+	}
+	d.pending++
+}
+
+func (d *WebhookDispatcher) donePending() {
+	d.pendingMu.Lock()
+	defer d.pendingMu.Unlock()
+
+	d.pending--
+	if d.pending == 0 {
+		close(d.idle)
+	}
+}
+
+// Publish Ставит событие в очереди подписчиков, которым оно подходит. Если очередь заполнена,
+// ждет места до отмены ctx
+func (d *WebhookDispatcher) Publish(ctx context.Context, event WebhookEvent) error {
+	if event.ID == "" {
+		id, err := newWebhookEventID()
+		if err != nil {
+			return err
+		}
+		event.ID = id
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = d.cfg.Now()
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.stopped {
+		return ErrWebhookDispatcherStopped
+	}
+	for _, sub := range d.subscribers {
+		if !sub.accepts(event) {
+			continue
+		}
+		d.addPending()
+		select {
+		case sub.queue <- event:
+		case <-ctx.Done():
+			d.donePending()
+			return ctx.Err()
+		case <-d.stopping:
+			d.donePending()
+			return ErrWebhookDispatcherStopped
+		}
+	}
+	return nil
+}
+
+// PublishDriverEvent Публикует событие DriverSync. Для передачи в DriverSync.Run используйте DriverHandler
+func (d *WebhookDispatcher) PublishDriverEvent(ctx context.Context, e DriverEvent) error {
+	typ := WebhookDriverUpdated
+	if e.Type == DriverCreated {
+		typ = WebhookDriverCreated
+	}
+	return d.publishData(ctx, typ, e.ParkID, e.UpdatedAt, WebhookDriverData{
+		DriverID:  e.DriverID,
+		UpdatedAt: e.UpdatedAt,
+		Profile:   e.Profile,
+		Changes:   e.Changes,
+	})
+}
+
+// DriverHandler Возвращает обработчик для DriverSync.Sync и DriverSync.Run, публикующий события с контекстом ctx
+func (d *WebhookDispatcher) DriverHandler(ctx context.Context) func(DriverEvent) error {
+	return func(e DriverEvent) error {
+		return d.PublishDriverEvent(ctx, e)
+	}
+}
+
+// PublishStatusTransition Публикует смену текущего состояния водителя
+func (d *WebhookDispatcher) PublishStatusTransition(ctx context.Context, t StatusTransition) error {
+	return d.publishData(ctx, WebhookDriverStatusChanged, t.ParkID, t.At, WebhookStatusData{
+		DriverID: t.DriverID,
+		From:     t.From,
+		To:       t.To,
+	})
+}
+
+// PublishOrder Публикует заказ, полученный OrderSync
+func (d *WebhookDispatcher) PublishOrder(ctx context.Context, parkID ParkID, order Order) error {
+	return d.publishData(ctx, WebhookOrderSynced, parkID, time.Time{}, order)
+}
+
+// PublishTransactions Публикует каждую транзакцию пакета LedgerSync отдельным событием
+func (d *WebhookDispatcher) PublishTransactions(ctx context.Context, parkID ParkID, transactions []Transaction) error {
+	for _, tx := range transactions {
+		eventAt, _ := ParseTime(tx.EventAt)
+		if err := d.publishData(ctx, WebhookTransactionPosted, parkID, eventAt, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WatchStatus Подписывает рассылку на переходы StatusWatcher. Возвращает функцию отписки
+func (d *WebhookDispatcher) WatchStatus(w *StatusWatcher) func() {
+	return w.OnTransition(func(t StatusTransition) {
+		if err := d.PublishStatusTransition(context.Background(), t); err != nil {
+			d.cfg.Logger.Warn("fleet webhook publish failed",
+				"event", WebhookDriverStatusChanged,
+				"driver_id", t.DriverID,
+				"error", err,
+			)
+		}
+	})
+}
+
+func (d *WebhookDispatcher) publishData(ctx context.Context, typ WebhookEventType, parkID ParkID, at time.Time, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return d.Publish(ctx, WebhookEvent{Type: typ, OccurredAt: at, ParkID: parkID, Data: data})
+}
+
+func (d *WebhookDispatcher) run(ctx context.Context, sub *webhookSubscriber) {
+	defer d.workers.Done()
+
+	for event := range sub.queue {
+		d.deliver(ctx, sub, event)
+		d.donePending()
+	}
+}
+
+// deliver Доставляет событие с повторами, а при неудаче записывает его в dead-letter файл
+func (d *WebhookDispatcher) deliver(ctx context.Context, sub *webhookSubscriber, event WebhookEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		d.deadLetter(ctx, sub, event, 0, err)
+		return
+	}
+
+	attempt := 0
+	for ctx.Err() == nil {
+		attempt++
+		res, err := d.post(ctx, sub, event, body)
+		if err == nil && res.StatusCode/100 == 2 {
+			return
+		}
+		if err == nil {
+			err = fmt.Errorf("unexpected status %d", res.StatusCode)
+			if !retryableStatus(res.StatusCode) {
+				d.deadLetter(ctx, sub, event, attempt, err)
+				return
+			}
+		}
+		if attempt >= d.cfg.Retry.MaxAttempts {
+			d.deadLetter(ctx, sub, event, attempt, err)
+			return
+		}
+
+		timer := time.NewTimer(d.cfg.Retry.delay(attempt, res))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+	d.deadLetter(ctx, sub, event, attempt, ctx.Err())
+}
+
+// post Выполняет одну попытку доставки. Тело ответа прочитано и закрыто
+func (d *WebhookDispatcher) post(ctx context.Context, sub *webhookSubscriber, event WebhookEvent, body []byte) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	timestamp := d.cfg.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookHeaderEvent, string(event.Type))
+	req.Header.Set(WebhookHeaderDelivery, event.ID)
+	req.Header.Set(WebhookHeaderTimestamp, strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set(WebhookHeaderSignature, SignWebhook(sub.Secret, timestamp, body))
+
+	res, err := d.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+	return res, nil
+}
+
+func (d *WebhookDispatcher) deadLetter(ctx context.Context, sub *webhookSubscriber, event WebhookEvent, attempts int, err error) {
+	d.cfg.Logger.WarnContext(ctx, "fleet webhook delivery failed",
+		"subscriber", sub.Name,
+		"event", event.Type,
+		"delivery", event.ID,
+		"attempts", attempts,
+		"error", err,
+	)
+
+	d.deadMu.Lock()
+	defer d.deadMu.Unlock()
+
+	if d.deadLetters == nil {
+		return
+	}
+	letter := WebhookDeadLetter{
+		Subscriber: sub.Name,
+		URL:        sub.URL,
+		Event:      event,
+		Attempts:   attempts,
+		Error:      err.Error(),
+		FailedAt:   d.cfg.Now(),
+	}
+	if err := d.deadLetters.append(letter); err != nil {
+		d.cfg.Logger.ErrorContext(ctx, "fleet webhook dead letter write failed",
+			"subscriber", sub.Name,
+			"delivery", event.ID,
+			"error", err,
+		)
+	}
+}
+
+// ReadWebhookDeadLetters Читает dead-letter файл рассылки. Файл открывается только для чтения, поэтому
+// его можно читать во время работы рассылки; недописанная последняя строка пропускается.
+// Отсутствующий файл считается пустым
+func ReadWebhookDeadLetters(path string) ([]WebhookDeadLetter, error) {
+	var letters []WebhookDeadLetter
+	err := readJSONL(path, func(line []byte) error {
+		var letter WebhookDeadLetter
+		if err := json.Unmarshal(line, &letter); err != nil {
+			return err
+		}
+		letters = append(letters, letter)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return letters, nil
+}
+
+// SignWebhook Подпись события: "sha256=" и HMAC-SHA256 ключом secret от строки
+// "<timestamp в секундах Unix>.<тело запроса>" в шестнадцатеричном виде
+func SignWebhook(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook Проверяет подпись запроса с событием. Запросы старше tolerance относительно now
+// отклоняются, чтобы перехваченный запрос нельзя было повторить. tolerance 0 отключает проверку времени
+func VerifyWebhook(secret string, header http.Header, body []byte, tolerance time.Duration, now time.Time) error {
+	seconds, err := strconv.ParseInt(header.Get(WebhookHeaderTimestamp), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s header", WebhookHeaderTimestamp)
+	}
+	timestamp := time.Unix(seconds, 0)
+	if tolerance > 0 && (now.Sub(timestamp) > tolerance || timestamp.Sub(now) > tolerance) {
+		return fmt.Errorf("webhook timestamp %s is outside tolerance", timestamp.UTC().Format(time.RFC3339))
+	}
+
+	want := SignWebhook(secret, timestamp, body)
+	if !hmac.Equal([]byte(want), []byte(header.Get(WebhookHeaderSignature))) {
+		return errors.New("webhook signature mismatch")
+	}
+	return nil
+}
+
+func newWebhookEventID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
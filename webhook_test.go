@@ -0,0 +1,334 @@
+package yandex_taxi_go_test
+
+import (
+	"context"
+	"encoding/json"
+	fleet "github.com/sinland/yandex-taxi-go"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// webhookReceiver Тестовый получатель событий, проверяющий подпись
+type webhookReceiver struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	events     []fleet.WebhookEvent
+	deliveries []string
+}
+
+func newWebhookReceiver(t *testing.T, secret string, status func(attempt int) int) *webhookReceiver {
+	r := &webhookReceiver{}
+	var attempts atomic.Int32
+
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.NoError(t, fleet.VerifyWebhook(secret, req.Header, body, time.Minute, time.Now()))
+
+		var event fleet.WebhookEvent
+		require.NoError(t, json.Unmarshal(body, &event))
+		require.Equal(t, string(event.Type), req.Header.Get(fleet.WebhookHeaderEvent))
+		require.Equal(t, event.ID, req.Header.Get(fleet.WebhookHeaderDelivery))
+
+		code := http.StatusOK
+		if status != nil {
+			code = status(int(attempts.Add(1)))
+		}
+
+		r.mu.Lock()
+		r.deliveries = append(r.deliveries, event.ID)
+		if code == http.StatusOK {
+			r.events = append(r.events, event)
+		}
+		r.mu.Unlock()
+		w.WriteHeader(code)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *webhookReceiver) received() ([]fleet.WebhookEvent, []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]fleet.WebhookEvent(nil), r.events...), append([]string(nil), r.deliveries...)
+}
+
+func newTestDispatcher(t *testing.T, cfg fleet.WebhookDispatcherConfig) *fleet.WebhookDispatcher {
+	if cfg.Retry.MaxAttempts == 0 {
+		cfg.Retry = fleet.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	}
+	d, err := fleet.NewWebhookDispatcher(cfg)
+	require.NoError(t, err)
+	d.Start(context.Background())
+	t.Cleanup(d.Stop)
+	return d
+}
+
+func flushWebhooks(t *testing.T, d *fleet.WebhookDispatcher) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, d.Flush(ctx))
+}
+
+func TestWebhookDispatcher(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	statuses := newWebhookReceiver(t, "secret-1", nil)
+	all := newWebhookReceiver(t, "secret-2", nil)
+
+	d := newTestDispatcher(t, fleet.WebhookDispatcherConfig{
+		Subscribers: []fleet.WebhookSubscriber{
+			{Name: "statuses", URL: statuses.URL, Secret: "secret-1", Events: []fleet.WebhookEventType{fleet.WebhookDriverStatusChanged}},
+			{Name: "park-1", URL: all.URL, Secret: "secret-2", Filter: func(e fleet.WebhookEvent) bool { return e.ParkID == "park-1" }},
+		},
+	})
+
+	at := time.Date(2024, 3, 3, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, d.PublishDriverEvent(ctx, fleet.DriverEvent{
+		Type:      fleet.DriverUpdated,
+		ParkID:    "park-1",
+		DriverID:  "driver-1",
+		UpdatedAt: at,
+		Profile:   fleet.DriverProfile{Profile: &fleet.DriverProfileData{Id: "driver-1", LastName: "Петров"}},
+		Changes:   []fleet.FieldChange{{Field: "driver_profile.last_name", Old: "Иванов", New: "Петров"}},
+	}))
+	require.NoError(t, d.PublishStatusTransition(ctx, fleet.StatusTransition{ParkID: "park-1", DriverID: "driver-1", From: "online", To: "busy", At: at}))
+	require.NoError(t, d.PublishStatusTransition(ctx, fleet.StatusTransition{ParkID: "park-2", DriverID: "driver-2", From: "busy", To: "offline", At: at}))
+	require.NoError(t, d.PublishTransactions(ctx, "park-1", []fleet.Transaction{
+		{Id: "tx-1", EventAt: "2024-03-03T10:00:00+0300", Amount: "100.00"},
+		{Id: "tx-2", EventAt: "2024-03-03T10:01:00+0300", Amount: "-5.00"},
+	}))
+	flushWebhooks(t, d)
+
+	got, _ := statuses.received()
+	require.Len(t, got, 2)
+	require.Equal(t, fleet.ParkID("park-1"), got[0].ParkID)
+	require.Equal(t, fleet.ParkID("park-2"), got[1].ParkID)
+	var status fleet.WebhookStatusData
+	require.NoError(t, json.Unmarshal(got[0].Data, &status))
+	require.Equal(t, fleet.WebhookStatusData{DriverID: "driver-1", From: "online", To: "busy"}, status)
+
+	got, _ = all.received()
+	types := make([]fleet.WebhookEventType, 0, len(got))
+	for _, e := range got {
+		types = append(types, e.Type)
+	}
+	require.Equal(t, []fleet.WebhookEventType{
+		fleet.WebhookDriverUpdated,
+		fleet.WebhookDriverStatusChanged,
+		fleet.WebhookTransactionPosted,
+		fleet.WebhookTransactionPosted,
+	}, types)
+	require.True(t, at.Equal(got[0].OccurredAt))
+
+	var driver fleet.WebhookDriverData
+	require.NoError(t, json.Unmarshal(got[0].Data, &driver))
+	require.Equal(t, fleet.DriverID("driver-1"), driver.DriverID)
+	require.Equal(t, "Петров", driver.Profile.Profile.LastName)
+	require.Len(t, driver.Changes, 1)
+
+	var tx fleet.Transaction
+	require.NoError(t, json.Unmarshal(got[3].Data, &tx))
+	require.Equal(t, fleet.TransactionID("tx-2"), tx.Id)
+
+	t.Run("stopped", func(t *testing.T) {
+		d.Stop()
+		require.ErrorIs(t, d.PublishOrder(ctx, "park-1", fleet.Order{Id: "order-1"}), fleet.ErrWebhookDispatcherStopped)
+	})
+}
+
+func TestWebhookDispatcher_Retry(t *testing.T) {
+	t.Parallel()
+
+	receiver := newWebhookReceiver(t, "secret", func(attempt int) int {
+		if attempt < 3 {
+			return http.StatusServiceUnavailable
+		}
+		return http.StatusOK
+	})
+	d := newTestDispatcher(t, fleet.WebhookDispatcherConfig{
+		Subscribers: []fleet.WebhookSubscriber{{URL: receiver.URL, Secret: "secret"}},
+	})
+
+	require.NoError(t, d.PublishOrder(context.Background(), "park-1", fleet.Order{Id: "order-1", Status: "complete"}))
+	flushWebhooks(t, d)
+
+	events, deliveries := receiver.received()
+	require.Len(t, events, 1)
+	require.Len(t, deliveries, 3)
+	require.Equal(t, []string{events[0].ID, events[0].ID, events[0].ID}, deliveries, "retries must reuse delivery id")
+}
+
+func TestWebhookDispatcher_DeadLetter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "dead.jsonl")
+	rejecting := newWebhookReceiver(t, "secret", func(int) int { return http.StatusBadRequest })
+	failing := newWebhookReceiver(t, "secret", func(int) int { return http.StatusInternalServerError })
+
+	d := newTestDispatcher(t, fleet.WebhookDispatcherConfig{
+		Subscribers: []fleet.WebhookSubscriber{
+			{Name: "rejecting", URL: rejecting.URL, Secret: "secret"},
+			{Name: "failing", URL: failing.URL, Secret: "secret"},
+			{Name: "wrong-secret", URL: rejecting.URL, Secret: "other", Events: []fleet.WebhookEventType{fleet.WebhookDriverCreated}},
+		},
+		DeadLetterPath: path,
+	})
+
+	require.NoError(t, d.PublishOrder(ctx, "park-1", fleet.Order{Id: "order-1"}))
+	flushWebhooks(t, d)
+	d.Stop()
+
+	_, deliveries := rejecting.received()
+	require.Len(t, deliveries, 1, "4xx must not be retried")
+	_, deliveries = failing.received()
+	require.Len(t, deliveries, 3)
+
+	letters, err := fleet.ReadWebhookDeadLetters(path)
+	require.NoError(t, err)
+	require.Len(t, letters, 2)
+	bySubscriber := map[string]fleet.WebhookDeadLetter{}
+	for _, letter := range letters {
+		bySubscriber[letter.Subscriber] = letter
+	}
+	require.Equal(t, 1, bySubscriber["rejecting"].Attempts)
+	require.Equal(t, "unexpected status 400", bySubscriber["rejecting"].Error)
+	require.Equal(t, 3, bySubscriber["failing"].Attempts)
+	require.Equal(t, fleet.WebhookOrderSynced, bySubscriber["failing"].Event.Type)
+
+	var order fleet.Order
+	require.NoError(t, json.Unmarshal(bySubscriber["failing"].Event.Data, &order))
+	require.Equal(t, fleet.OrderID("order-1"), order.Id)
+}
+
+func TestWebhookDispatcher_DriverHandler(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, _, c := newSyncServer(t)
+	require.NoError(t, s.PutDriver("park-1", testDriver("driver-1", "+79990000001")))
+	require.NoError(t, s.PutDriver("park-1", testDriver("driver-2", "+79990000002")))
+
+	receiver := newWebhookReceiver(t, "secret", nil)
+	d := newTestDispatcher(t, fleet.WebhookDispatcherConfig{
+		Subscribers: []fleet.WebhookSubscriber{{Name: "drivers", URL: receiver.URL, Secret: "secret"}},
+	})
+
+	n, err := newDriverSync(t, c, fleet.DriverSyncConfig{ParkID: "park-1"}).Sync(ctx, d.DriverHandler(ctx))
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	flushWebhooks(t, d)
+
+	got, _ := receiver.received()
+	require.Len(t, got, 2)
+	ids := make([]fleet.DriverID, 0, len(got))
+	for _, e := range got {
+		require.Equal(t, fleet.WebhookDriverCreated, e.Type)
+		require.Equal(t, fleet.ParkID("park-1"), e.ParkID)
+		var driver fleet.WebhookDriverData
+		require.NoError(t, json.Unmarshal(e.Data, &driver))
+		ids = append(ids, driver.DriverID)
+	}
+	require.ElementsMatch(t, []fleet.DriverID{"driver-1", "driver-2"}, ids)
+}
+
+func TestReadWebhookDeadLetters_ReadOnly(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "dead.jsonl")
+	letters, err := fleet.ReadWebhookDeadLetters(path)
+	require.NoError(t, err)
+	require.Empty(t, letters)
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+
+	// Недописанная последняя строка пропускается, а сам файл не изменяется
+	data := []byte(`{"subscriber":"a","attempts":1}` + "\n" + `{"subscriber":"b","att`)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	letters, err = fleet.ReadWebhookDeadLetters(path)
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	require.Equal(t, 1, letters[0].Attempts)
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+}
+
+func TestWebhookDispatcher_StopDeadLettersQueue(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "dead.jsonl")
+	d, err := fleet.NewWebhookDispatcher(fleet.WebhookDispatcherConfig{
+		Subscribers:    []fleet.WebhookSubscriber{{URL: "http://127.0.0.1:1/hook", Secret: "secret"}},
+		DeadLetterPath: path,
+	})
+	require.NoError(t, err)
+
+	// События, опубликованные до Start, не теряются при остановке
+	require.NoError(t, d.PublishOrder(context.Background(), "park-1", fleet.Order{Id: "order-1"}))
+	require.NoError(t, d.PublishOrder(context.Background(), "park-1", fleet.Order{Id: "order-2"}))
+	d.Stop()
+
+	letters, err := fleet.ReadWebhookDeadLetters(path)
+	require.NoError(t, err)
+	require.Len(t, letters, 2)
+	require.Equal(t, 0, letters[0].Attempts)
+	require.Equal(t, context.Canceled.Error(), letters[0].Error)
+}
+
+func TestWebhookDispatcher_WatchStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s, _, c := newSyncServer(t)
+	putStatus(t, s, "driver-1", "online", "2024-03-03T09:00:00+0000")
+
+	receiver := newWebhookReceiver(t, "secret", nil)
+	d := newTestDispatcher(t, fleet.WebhookDispatcherConfig{
+		Subscribers: []fleet.WebhookSubscriber{{URL: receiver.URL, Secret: "secret"}},
+	})
+
+	w := fleet.NewStatusWatcher(c, fleet.StatusWatcherConfig{ParkID: "park-1"})
+	unsubscribe := d.WatchStatus(w)
+	defer unsubscribe()
+
+	_, err := w.Poll(ctx)
+	require.NoError(t, err)
+	putStatus(t, s, "driver-1", "busy", "2024-03-03T10:00:00+0000")
+	_, err = w.Poll(ctx)
+	require.NoError(t, err)
+	flushWebhooks(t, d)
+
+	events, _ := receiver.received()
+	require.Len(t, events, 1)
+	require.Equal(t, fleet.WebhookDriverStatusChanged, events[0].Type)
+	require.True(t, time.Date(2024, 3, 3, 10, 0, 0, 0, time.UTC).Equal(events[0].OccurredAt))
+}
+
+func TestVerifyWebhook(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 3, 10, 0, 0, 0, time.UTC)
+	body := []byte(`{"id":"1"}`)
+	header := http.Header{}
+	header.Set(fleet.WebhookHeaderTimestamp, "1709460000")
+	header.Set(fleet.WebhookHeaderSignature, fleet.SignWebhook("secret", now, body))
+
+	require.NoError(t, fleet.VerifyWebhook("secret", header, body, time.Minute, now))
+	require.Error(t, fleet.VerifyWebhook("other", header, body, time.Minute, now))
+	require.Error(t, fleet.VerifyWebhook("secret", header, []byte(`{"id":"2"}`), time.Minute, now))
+	require.Error(t, fleet.VerifyWebhook("secret", header, body, time.Minute, now.Add(time.Hour)))
+	require.NoError(t, fleet.VerifyWebhook("secret", header, body, 0, now.Add(time.Hour)))
+}